@@ -0,0 +1,100 @@
+package gcron
+
+import (
+    "sync"
+    "time"
+)
+
+// maxExcludedDays CalendarSchedule 查找下一次激活时间时最多连续跳过的天数。
+const maxExcludedDays = 366 * 5
+
+// Calendar 业务日历接口，用于排除节假日等不应运行任务的日期。
+type Calendar interface {
+    // Excluded 如果 t 所在的日期被排除，则返回 true。
+    Excluded(t time.Time) bool
+}
+
+var (
+    _ Calendar = (*DateCalendar)(nil)
+    _ Calendar = (*ICalCalendar)(nil)
+)
+
+// date 不含时区的日期。
+type date struct {
+    year  int
+    month time.Month
+    day   int
+}
+
+// dateOf 返回 t 在其时区中的日期。
+func dateOf(t time.Time) date {
+    year, month, day := t.Date()
+    return date{year, month, day}
+}
+
+// DateCalendar 排除指定日期的日历，日期按被检查时间所在的时区判断。
+type DateCalendar struct {
+    mu    sync.RWMutex
+    dates map[date]struct{}
+}
+
+// NewDateCalendar 新建一个排除 dates 所在日期的日历。
+func NewDateCalendar(dates ...time.Time) *DateCalendar {
+    c := &DateCalendar{dates: make(map[date]struct{}, len(dates))}
+    c.Add(dates...)
+    return c
+}
+
+// Add 排除 dates 所在的日期。
+func (c *DateCalendar) Add(dates ...time.Time) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for _, t := range dates {
+        c.dates[dateOf(t)] = struct{}{}
+    }
+}
+
+// Excluded 如果 t 所在的日期被排除，则返回 true。
+func (c *DateCalendar) Excluded(t time.Time) bool {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    _, ok := c.dates[dateOf(t)]
+    return ok
+}
+
+// CalendarSchedule 跳过日历所排除日期的计划。
+type CalendarSchedule struct {
+    Schedule Schedule
+    Calendar Calendar
+}
+
+// ExcludeCalendar 返回跳过 calendar 所排除日期的装饰器。
+func ExcludeCalendar(calendar Calendar) ScheduleWrapper {
+    return func(s Schedule) Schedule {
+        return CalendarSchedule{Schedule: s, Calendar: calendar}
+    }
+}
+
+// Next 返回内部调度下一个不在排除日期内的激活时间。
+// 激活时间落在排除日期时从次日零点起继续查找，连续排除超过五年时返回零时间。
+func (s CalendarSchedule) Next(t time.Time) time.Time {
+    next := s.Schedule.Next(t)
+    for skipped := 0; !next.IsZero() && s.Calendar.Excluded(next); skipped++ {
+        if skipped == maxExcludedDays {
+            return time.Time{}
+        }
+        year, month, day := next.Date()
+        next = s.Schedule.Next(time.Date(year, month, day+1, 0, 0, 0, 0, next.Location()).Add(-time.Nanosecond))
+    }
+    return next
+}
+
+// Expired 如果内部调度已过期，则返回 true。
+func (s CalendarSchedule) Expired(now time.Time, runs int) bool {
+    return expired(s.Schedule, now, runs)
+}
+
+// FixedDelay 如果内部调度是固定延迟的，则返回 true。
+func (s CalendarSchedule) FixedDelay() bool {
+    return fixedDelay(s.Schedule)
+}
@@ -0,0 +1,62 @@
+package gcron
+
+import (
+    "testing"
+    "time"
+)
+
+func TestCalendarSchedule(t *testing.T) {
+    loc, _ := time.LoadLocation("Asia/Shanghai")
+    day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, loc) }
+    holidays := NewDateCalendar(day(10, 1), day(10, 2), day(10, 3))
+
+    parser := NewParser(Minute | Hour | Dom | Month | Dow).WithCalendar("holidays", holidays)
+    schedule, err := parser.Parse("CAL=holidays TZ=Asia/Shanghai 0 18 * * *")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, ok := schedule.(CalendarSchedule); !ok {
+        t.Fatalf("expected CalendarSchedule, got %T", schedule)
+    }
+
+    tests := []struct {
+        from, expected time.Time
+    }{
+        {day(9, 30), day(9, 30).Add(18 * time.Hour)},
+        {day(9, 30).Add(19 * time.Hour), day(10, 4).Add(18 * time.Hour)},
+        {day(10, 2).Add(12 * time.Hour), day(10, 4).Add(18 * time.Hour)},
+    }
+    for _, test := range tests {
+        if actual := schedule.Next(test.from); !actual.Equal(test.expected) {
+            t.Errorf("Next(%v) = %v, expected %v", test.from, actual, test.expected)
+        }
+    }
+
+    holidays.Add(day(10, 4))
+    if actual, expected := schedule.Next(day(10, 1)), day(10, 5).Add(18*time.Hour); !actual.Equal(expected) {
+        t.Errorf("expected %v after adding a holiday, got %v", expected, actual)
+    }
+}
+
+func TestCalendarScheduleAllExcluded(t *testing.T) {
+    all := calendarFunc(func(time.Time) bool { return true })
+    schedule := ExcludeCalendar(all)(Every(time.Hour))
+    if next := schedule.Next(time.Now()); !next.IsZero() {
+        t.Errorf("expected zero time, got %v", next)
+    }
+}
+
+func TestParseUnknownCalendar(t *testing.T) {
+    parser := NewParser(Minute | Hour | Dom | Month | Dow)
+    if _, err := parser.Parse("CAL=missing 0 18 * * *"); err == nil {
+        t.Error("expected error for unknown calendar")
+    }
+    if _, err := parser.WithCalendar("holidays", NewDateCalendar()).Parse("CAL=holidays"); err == nil {
+        t.Error("expected error for missing schedule")
+    }
+}
+
+// calendarFunc 将函数用作日历。
+type calendarFunc func(time.Time) bool
+
+func (f calendarFunc) Excluded(t time.Time) bool { return f(t) }
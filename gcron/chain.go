@@ -1,6 +1,10 @@
 package gcron
 
 import (
+    "context"
+    "errors"
+    "fmt"
+    "math/rand/v2"
     "runtime/debug"
     "sync"
     "time"
@@ -12,7 +16,7 @@ import (
 type JobWrapper func(Job) Job
 
 // Chain 是一个 JobWrappers 序列，用于装饰提交的任务。
-// cross-cutting behaviors like logging or synchronization.
+// 内置的包装器会将上下文透传给实现了 ContextJob 的任务。
 type Chain struct {
     wrappers []JobWrapper
 }
@@ -38,17 +42,19 @@ func (c Chain) Then(j Job) Job {
     return j
 }
 
-// Recover 使用日志记录器，记录包装任务中的 panic。
+// Recover 使用日志记录器，记录包装任务中的 panic，并将其转换为错误返回给外层包装器。
 func Recover() JobWrapper {
     return func(j Job) Job {
-        return FuncJob(func() {
+        ej := toErrorJob(j)
+        return FuncErrorJob(func(ctx context.Context) (err error) {
             defer func() {
                 if r := recover(); r != nil {
                     glog.Errorf(`gcron Recover panic: %v`, r)
                     glog.Errorf(`gcron Recover stack: %v`, string(debug.Stack()))
+                    err = fmt.Errorf("panic: %v", r)
                 }
             }()
-            j.Run()
+            return ej.RunE(ctx)
         })
     }
 }
@@ -57,31 +63,137 @@ func Recover() JobWrapper {
 func DelayIfStillRunning() JobWrapper {
     return func(j Job) Job {
         var mu sync.Mutex
-        return FuncJob(func() {
+        ej := toErrorJob(j)
+        return FuncErrorJob(func(ctx context.Context) error {
             start := time.Now()
             mu.Lock()
             defer mu.Unlock()
             if dur := time.Since(start); dur > time.Minute {
                 glog.Debugw(glog.DefaultMessageKey, "Cron", "action", "delay", "duration", dur)
             }
-            j.Run()
+            return ej.RunE(ctx)
         })
     }
 }
 
 // SkipIfStillRunning 如果先前的调用仍在运行，则跳过对 Job 的调用。它记录跳转到信息级别的给定记录器。
+// 由 Cron 调度的运行被跳过时，会记录到条目历史并触发 OnJobSkipped 钩子。
 func SkipIfStillRunning() JobWrapper {
     return func(j Job) Job {
         var ch = make(chan struct{}, 1)
         ch <- struct{}{}
-        return FuncJob(func() {
+        ej := toErrorJob(j)
+        return FuncErrorJob(func(ctx context.Context) error {
             select {
             case v := <-ch:
                 defer func() { ch <- v }()
-                j.Run()
+                return ej.RunE(ctx)
             default:
                 glog.Debug("skip")
+                reportSkipped(ctx)
+                return nil
             }
         })
     }
 }
+
+// defaultLockTTL SingletonAcrossInstances 默认的锁有效期。
+const defaultLockTTL = time.Minute
+
+// SingletonAcrossInstances 使用分布式锁保证多个实例中同一条目的同一调度时间只运行一次。
+// 锁以条目名称（未命名时为条目 ID）加调度时间为键，因此各实例应使用相同的条目名称。
+// 锁不会在运行结束后释放而是持有至 ttl（默认一分钟）到期，以免时钟略有偏差的实例再次获取同一调度时间的锁。
+// 未能获取锁时跳过本次运行；不是由 Cron 调度的运行没有调度信息，直接运行。
+func SingletonAcrossInstances(locker Locker, ttl ...time.Duration) JobWrapper {
+    lockTTL := defaultLockTTL
+    if len(ttl) > 0 && ttl[0] > 0 {
+        lockTTL = ttl[0]
+    }
+    return func(j Job) Job {
+        ej := toErrorJob(j)
+        return FuncErrorJob(func(ctx context.Context) error {
+            info, ok := JobInfoFromContext(ctx)
+            if !ok {
+                return ej.RunE(ctx)
+            }
+            name := info.Name
+            if name == "" {
+                name = fmt.Sprintf("entry-%d", info.ID)
+            }
+            key := fmt.Sprintf("%s@%d", name, info.Scheduled.UnixNano())
+            locked, err := locker.Lock(ctx, key, lockTTL)
+            if err != nil {
+                glog.Errorf(`gcron SingletonAcrossInstances lock %s: %v`, key, err)
+                return err
+            }
+            if !locked {
+                glog.Debugw(glog.DefaultMessageKey, "Cron", "action", "locked", "key", key)
+                reportSkipped(ctx)
+                return nil
+            }
+            return ej.RunE(ctx)
+        })
+    }
+}
+
+// retryDelay 返回第 i+1 次重试前抖动之前的等待时间 backoff*2^i，不超过 max(backoff, maxRetryDelay)。
+func retryDelay(backoff time.Duration, i int) time.Duration {
+    if backoff > maxRetryDelay>>i {
+        return max(backoff, maxRetryDelay)
+    }
+    return backoff << i
+}
+
+// Timeout 为每次运行设置超时时间，超时后取消任务的上下文。
+// 任务需要通过上下文感知取消才能及时退出；超时的运行会记录日志并返回包含 context.DeadlineExceeded 的错误。
+func Timeout(d time.Duration) JobWrapper {
+    return func(j Job) Job {
+        ej := toErrorJob(j)
+        return FuncErrorJob(func(ctx context.Context) error {
+            ctx, cancel := context.WithTimeout(ctx, d)
+            defer cancel()
+            start := time.Now()
+            err := ej.RunE(ctx)
+            if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+                return err
+            }
+            dur := time.Since(start)
+            glog.Warnw(glog.DefaultMessageKey, "Cron", "action", "timeout", "timeout", d, "duration", dur)
+            if err == nil {
+                err = ctx.Err()
+            }
+            return fmt.Errorf("job timed out after %s (ran %s): %w", d, dur, err)
+        })
+    }
+}
+
+// maxRetryDelay Retry 指数退避的等待时间上限，backoff 本身更大时以 backoff 为上限。
+const maxRetryDelay = time.Hour
+
+// Retry 在任务返回错误时重试最多 n 次，第 i 次重试前等待 backoff*2^(i-1)（最多一小时），并随机抖动到 [50%, 100%]。
+// 与 Recover 组合为 NewChain(Retry(n, backoff), Recover()) 时，panic 同样会被重试。
+// 等待期间上下文被取消时停止重试并返回最后一次的错误。
+func Retry(n int, backoff time.Duration) JobWrapper {
+    return func(j Job) Job {
+        ej := toErrorJob(j)
+        return FuncErrorJob(func(ctx context.Context) error {
+            err := ej.RunE(ctx)
+            for i := 0; i < n && err != nil; i++ {
+                delay := retryDelay(backoff, i)
+                if half := int64(delay / 2); half > 0 {
+                    delay = time.Duration(half + rand.Int64N(half+1))
+                }
+                glog.Debugw(glog.DefaultMessageKey, "Cron", "action", "retry", "attempt", i+1, "delay", delay, "error", err)
+                timer := time.NewTimer(delay)
+                select {
+                case <-ctx.Done():
+                    timer.Stop()
+                    return err
+                case <-timer.C:
+                }
+                err = ej.RunE(ctx)
+            }
+            return err
+        })
+    }
+}
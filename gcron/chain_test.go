@@ -1,6 +1,8 @@
 package gcron
 
 import (
+    "context"
+    "errors"
     "reflect"
     "sync"
     "testing"
@@ -67,6 +69,19 @@ func TestChainRecover(t *testing.T) {
     })
 }
 
+func TestChainPassesContext(t *testing.T) {
+    type ctxKey struct{}
+    var got any
+    job := FuncContextJob(func(ctx context.Context) {
+        got = ctx.Value(ctxKey{})
+    })
+    wrapped := NewChain(Recover(), DelayIfStillRunning(), SkipIfStillRunning()).Then(job)
+    toContextJob(wrapped).RunContext(context.WithValue(context.Background(), ctxKey{}, "v"))
+    if got != "v" {
+        t.Errorf("expected context to reach the job, got %v", got)
+    }
+}
+
 type countJob struct {
     m       sync.Mutex
     started int
@@ -234,3 +249,111 @@ func TestChainSkipIfStillRunning(t *testing.T) {
         }
     })
 }
+
+func TestChainRecoverReturnsError(t *testing.T) {
+    job := NewChain(Recover()).Then(FuncJob(func() { panic("boom") }))
+    err := toErrorJob(job).RunE(context.Background())
+    if err == nil || err.Error() != "panic: boom" {
+        t.Error("expected panic converted to error, got", err)
+    }
+}
+
+func TestChainTimeout(t *testing.T) {
+    t.Run("cancels overrunning job", func(t *testing.T) {
+        job := NewChain(Timeout(20 * time.Millisecond)).Then(FuncContextJob(func(ctx context.Context) {
+            <-ctx.Done()
+        }))
+        start := time.Now()
+        err := toErrorJob(job).RunE(context.Background())
+        if !errors.Is(err, context.DeadlineExceeded) {
+            t.Error("expected deadline exceeded, got", err)
+        }
+        if dur := time.Since(start); dur > time.Second {
+            t.Error("expected job cancelled after timeout, took", dur)
+        }
+    })
+
+    t.Run("keeps job error", func(t *testing.T) {
+        jobErr := errors.New("failed")
+        job := NewChain(Timeout(time.Second)).Then(FuncErrorJob(func(ctx context.Context) error {
+            return jobErr
+        }))
+        if err := toErrorJob(job).RunE(context.Background()); err != jobErr {
+            t.Error("expected job error, got", err)
+        }
+    })
+}
+
+func TestChainRetry(t *testing.T) {
+    t.Run("retries until success", func(t *testing.T) {
+        var calls int
+        job := NewChain(Retry(3, time.Millisecond)).Then(FuncErrorJob(func(ctx context.Context) error {
+            calls++
+            if calls < 3 {
+                return errors.New("failed")
+            }
+            return nil
+        }))
+        if err := toErrorJob(job).RunE(context.Background()); err != nil || calls != 3 {
+            t.Error("expected success on third call, got", err, calls)
+        }
+    })
+
+    t.Run("gives up after n retries", func(t *testing.T) {
+        var calls int
+        job := NewChain(Retry(2, time.Millisecond)).Then(FuncErrorJob(func(ctx context.Context) error {
+            calls++
+            return errors.New("failed")
+        }))
+        if err := toErrorJob(job).RunE(context.Background()); err == nil || calls != 3 {
+            t.Error("expected error after 3 calls, got", err, calls)
+        }
+    })
+
+    t.Run("retries recovered panic", func(t *testing.T) {
+        var calls int
+        job := NewChain(Retry(1, time.Millisecond), Recover()).Then(FuncJob(func() {
+            calls++
+            if calls == 1 {
+                panic("boom")
+            }
+        }))
+        if err := toErrorJob(job).RunE(context.Background()); err != nil || calls != 2 {
+            t.Error("expected success on retry, got", err, calls)
+        }
+    })
+
+    t.Run("stops on cancelled context", func(t *testing.T) {
+        var calls int
+        ctx, cancel := context.WithCancel(context.Background())
+        job := NewChain(Retry(5, time.Hour)).Then(FuncErrorJob(func(ctx context.Context) error {
+            calls++
+            cancel()
+            return errors.New("failed")
+        }))
+        if err := toErrorJob(job).RunE(ctx); err == nil || calls != 1 {
+            t.Error("expected single call, got", err, calls)
+        }
+    })
+}
+
+func TestRetryDelay(t *testing.T) {
+    tests := []struct {
+        backoff time.Duration
+        i       int
+        want    time.Duration
+    }{
+        {time.Second, 0, time.Second},
+        {time.Second, 3, 8 * time.Second},
+        {time.Second, 12, maxRetryDelay},
+        {time.Second, 64, maxRetryDelay},
+        {time.Second, 1000, maxRetryDelay},
+        {2 * time.Hour, 1, 2 * time.Hour},
+        {0, 100, 0},
+    }
+    for _, test := range tests {
+        if got := retryDelay(test.backoff, test.i); got != test.want {
+            t.Errorf("retryDelay(%v, %d) = %v, want %v", test.backoff, test.i, got, test.want)
+        }
+    }
+}
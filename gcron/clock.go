@@ -0,0 +1,127 @@
+package gcron
+
+import (
+    "sync"
+    "time"
+)
+
+// Clock Cron 使用的时间来源，默认使用 time 包。
+// 测试时可替换为 FakeClock，手动推进时间以立即、确定地触发任务。
+type Clock interface {
+    // Now 返回当前时间。
+    Now() time.Time
+    // NewTimer 新建一个在 d 之后触发的计时器。
+    NewTimer(d time.Duration) Timer
+}
+
+// Timer Clock 创建的计时器。
+type Timer interface {
+    // C 返回计时器触发时接收当前时间的通道。
+    C() <-chan time.Time
+    // Stop 停止计时器，如果计时器已触发或已停止则返回 false。
+    Stop() bool
+}
+
+var (
+    _ Clock = realClock{}
+    _ Clock = (*FakeClock)(nil)
+)
+
+// realClock 基于 time 包的时间来源。
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+// realTimer 基于 time.Timer 的计时器。
+type realTimer struct {
+    *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+// FakeClock 手动推进的时间来源，用于测试。
+type FakeClock struct {
+    mu     sync.Mutex
+    cond   *sync.Cond
+    now    time.Time
+    timers []*fakeTimer
+}
+
+// NewFakeClock 新建一个当前时间为 now 的 FakeClock。
+func NewFakeClock(now time.Time) *FakeClock {
+    c := &FakeClock{now: now}
+    c.cond = sync.NewCond(&c.mu)
+    return c
+}
+
+// Now 返回当前时间。
+func (c *FakeClock) Now() time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.now
+}
+
+// NewTimer 新建一个在 d 之后触发的计时器，d 小于等于 0 时立即触发。
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    t := &fakeTimer{clock: c, deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+    if d <= 0 {
+        t.c <- c.now
+        return t
+    }
+    c.timers = append(c.timers, t)
+    c.cond.Broadcast()
+    return t
+}
+
+// Advance 将时间推进 d，并触发所有到期的计时器。
+func (c *FakeClock) Advance(d time.Duration) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.now = c.now.Add(d)
+    timers := c.timers[:0]
+    for _, t := range c.timers {
+        if t.deadline.After(c.now) {
+            timers = append(timers, t)
+            continue
+        }
+        t.c <- c.now
+    }
+    c.timers = timers
+    c.cond.Broadcast()
+}
+
+// BlockUntil 阻塞直到至少有 n 个计时器在等待触发。
+// Cron 在后台 goroutine 中创建计时器，推进时间前应先等待其就绪。
+func (c *FakeClock) BlockUntil(n int) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for len(c.timers) < n {
+        c.cond.Wait()
+    }
+}
+
+// fakeTimer FakeClock 创建的计时器。
+type fakeTimer struct {
+    clock    *FakeClock
+    deadline time.Time
+    c        chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Stop() bool {
+    t.clock.mu.Lock()
+    defer t.clock.mu.Unlock()
+    for i, timer := range t.clock.timers {
+        if timer == t {
+            t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+            t.clock.cond.Broadcast()
+            return true
+        }
+    }
+    return false
+}
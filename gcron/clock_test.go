@@ -0,0 +1,126 @@
+package gcron
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+func TestFakeClock(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    clock := NewFakeClock(start)
+
+    timer := clock.NewTimer(time.Minute)
+    stopped := clock.NewTimer(time.Minute)
+    if !stopped.Stop() || stopped.Stop() {
+        t.Error("expected first stop to succeed only")
+    }
+
+    clock.Advance(59 * time.Second)
+    select {
+    case <-timer.C():
+        t.Fatal("timer fired early")
+    default:
+    }
+
+    clock.Advance(time.Second)
+    select {
+    case now := <-timer.C():
+        if !now.Equal(start.Add(time.Minute)) {
+            t.Errorf("unexpected fire time %v", now)
+        }
+    default:
+        t.Fatal("expected timer to fire")
+    }
+    if timer.Stop() {
+        t.Error("expected stop of fired timer to return false")
+    }
+    select {
+    case <-stopped.C():
+        t.Error("stopped timer fired")
+    default:
+    }
+    if !clock.Now().Equal(start.Add(time.Minute)) {
+        t.Errorf("unexpected now %v", clock.Now())
+    }
+}
+
+func TestCronWithFakeClock(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    clock := NewFakeClock(start)
+    cron := New(WithClock(clock), WithLocation(time.UTC))
+
+    fired := make(chan time.Time, 10)
+    cron.AddContextFunc("0 * * * *", func(ctx context.Context) {
+        info, _ := JobInfoFromContext(ctx)
+        fired <- info.Scheduled
+    })
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    clock.BlockUntil(1)
+    clock.Advance(59 * time.Minute)
+    select {
+    case <-fired:
+        t.Fatal("job fired early")
+    default:
+    }
+
+    for i := 1; i <= 3; i++ {
+        expected := start.Add(time.Duration(i) * time.Hour)
+        clock.BlockUntil(1)
+        clock.Advance(expected.Sub(clock.Now()))
+        select {
+        case scheduled := <-fired:
+            if !scheduled.Equal(expected) {
+                t.Errorf("expected run at %v, got %v", expected, scheduled)
+            }
+        case <-time.After(time.Second):
+            t.Fatalf("expected run %d", i)
+        }
+    }
+}
+
+func TestCronFixedDelay(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    clock := NewFakeClock(start)
+    cron := New(WithClock(clock), WithLocation(time.UTC))
+
+    fired, release := make(chan time.Time, 2), make(chan struct{})
+    id := cron.Schedule(FixedDelay(time.Second), FuncContextJob(func(ctx context.Context) {
+        info, _ := JobInfoFromContext(ctx)
+        fired <- info.Scheduled
+        <-release
+    }))
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    clock.BlockUntil(1)
+    clock.Advance(time.Second)
+    if scheduled := <-fired; !scheduled.Equal(start.Add(time.Second)) {
+        t.Errorf("unexpected first run %v", scheduled)
+    }
+
+    // 任务运行 5 秒，期间不会再次调度。
+    clock.Advance(5 * time.Second)
+    if next := cron.Entry(id).Next; !next.IsZero() {
+        t.Errorf("expected no next run while running, got %v", next)
+    }
+    release <- struct{}{}
+
+    deadline := time.Now().Add(time.Second)
+    for cron.Entry(id).Next.IsZero() {
+        if time.Now().After(deadline) {
+            t.Fatal("expected next run scheduled after completion")
+        }
+        time.Sleep(time.Millisecond)
+    }
+    if next := cron.Entry(id).Next; !next.Equal(start.Add(7 * time.Second)) {
+        t.Errorf("expected next run one second after completion, got %v", next)
+    }
+    clock.Advance(time.Second)
+    if scheduled := <-fired; !scheduled.Equal(start.Add(7 * time.Second)) {
+        t.Errorf("unexpected second run %v", scheduled)
+    }
+    close(release)
+}
@@ -10,7 +10,7 @@ type ConstantDelaySchedule struct {
 
 // Every 返回每个持续时间激活一次的 crontab 计划。
 // 不支持小于一秒的延迟（将四舍五入到 1 秒）。
-// 任何小于秒的字段都会被截断。
+// 任何小于秒的字段都会被截断，需要毫秒精度时请使用 FixedRate 或 FixedDelay。
 func Every(duration time.Duration) ConstantDelaySchedule {
     if duration < time.Second {
         duration = time.Second
@@ -24,3 +24,50 @@ func Every(duration time.Duration) ConstantDelaySchedule {
 func (schedule ConstantDelaySchedule) Next(t time.Time) time.Time {
     return t.Add(schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
 }
+
+// FixedRateSchedule 以毫秒精度按固定频率激活的计划，激活时间与时钟对齐，不受任务耗时影响。
+// 例如间隔为 500ms 时总是在每秒的 0ms 和 500ms 激活。
+type FixedRateSchedule struct {
+    Interval time.Duration
+}
+
+// FixedRate 返回每隔 interval 激活一次、与时钟对齐的计划。
+// interval 精确到毫秒，小于一毫秒时按一毫秒处理。
+func FixedRate(interval time.Duration) FixedRateSchedule {
+    return FixedRateSchedule{Interval: truncateMillisecond(interval)}
+}
+
+// Next 返回 t 之后第一个与 Interval 对齐的时间。
+func (schedule FixedRateSchedule) Next(t time.Time) time.Time {
+    return t.Truncate(schedule.Interval).Add(schedule.Interval)
+}
+
+// FixedDelaySchedule 以毫秒精度按固定延迟激活的计划，下一次运行从上一次运行结束时开始计算。
+// Cron 在任务运行期间不调度该条目，任务结束后才计算下一次运行时间。
+type FixedDelaySchedule struct {
+    Delay time.Duration
+}
+
+// FixedDelay 返回每次任务结束 delay 之后再次激活的计划。
+// delay 精确到毫秒，小于一毫秒时按一毫秒处理。
+func FixedDelay(delay time.Duration) FixedDelaySchedule {
+    return FixedDelaySchedule{Delay: truncateMillisecond(delay)}
+}
+
+// Next 返回 t 之后 Delay 的时间，t 为上一次运行的结束时间。
+func (schedule FixedDelaySchedule) Next(t time.Time) time.Time {
+    return t.Add(schedule.Delay)
+}
+
+// FixedDelay 总是返回 true。
+func (schedule FixedDelaySchedule) FixedDelay() bool {
+    return true
+}
+
+// truncateMillisecond 将 d 截断到毫秒，最小为一毫秒。
+func truncateMillisecond(d time.Duration) time.Duration {
+    if d < time.Millisecond {
+        return time.Millisecond
+    }
+    return d.Truncate(time.Millisecond)
+}
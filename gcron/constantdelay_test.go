@@ -43,3 +43,35 @@ func TestConstantDelayNext(t *testing.T) {
         }
     }
 }
+
+func TestFixedRateNext(t *testing.T) {
+    base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+    tests := []struct {
+        time     time.Time
+        interval time.Duration
+        expected time.Time
+    }{
+        {base, 500 * time.Millisecond, base.Add(500 * time.Millisecond)},
+        {base.Add(123 * time.Millisecond), 500 * time.Millisecond, base.Add(500 * time.Millisecond)},
+        {base.Add(499*time.Millisecond + 999*time.Microsecond), 500 * time.Millisecond, base.Add(500 * time.Millisecond)},
+        {base.Add(7 * time.Second), 5 * time.Second, base.Add(10 * time.Second)},
+        {base.Add(250 * time.Microsecond), 100*time.Millisecond + 900*time.Microsecond, base.Add(100 * time.Millisecond)},
+        {base.Add(250 * time.Microsecond), time.Microsecond, base.Add(time.Millisecond)},
+    }
+
+    for _, c := range tests {
+        if actual := FixedRate(c.interval).Next(c.time); !actual.Equal(c.expected) {
+            t.Errorf("%v, %v: (expected) %v != %v (actual)", c.time, c.interval, c.expected, actual)
+        }
+    }
+}
+
+func TestFixedDelayNext(t *testing.T) {
+    base := time.Date(2024, 1, 1, 12, 0, 0, 123456789, time.UTC)
+    if actual := FixedDelay(250*time.Millisecond + 999*time.Microsecond).Next(base); !actual.Equal(base.Add(250 * time.Millisecond)) {
+        t.Errorf("unexpected next %v", actual)
+    }
+    if !fixedDelay(FixedDelay(time.Second)) || fixedDelay(FixedRate(time.Second)) || !fixedDelay(Jitter(time.Millisecond)(FixedDelay(time.Second))) {
+        t.Error("unexpected fixed delay detection")
+    }
+}
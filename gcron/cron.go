@@ -2,7 +2,7 @@ package gcron
 
 import (
     "context"
-    "sort"
+    "fmt"
     "sync"
     "time"
 
@@ -12,19 +12,41 @@ import (
 // Cron 跟踪任意数量的条目，调用调度指定的关联函数。
 // Cron 可以启动、停止，并且可以在运行时检查条目。
 type Cron struct {
-    entries   []*Entry
-    chain     Chain
-    stop      chan struct{}
-    add       chan *Entry
-    remove    chan EntryID
-    snapshot  chan chan []Entry
-    running   bool
-    logger    *glog.Helper
-    runningMu sync.Mutex
-    location  *time.Location
-    parser    ScheduleParser
-    nextID    EntryID
-    jobWaiter sync.WaitGroup
+    entries    entryHeap
+    chain      Chain
+    stop       chan struct{}
+    add        chan *Entry
+    remove     chan EntryID
+    pause      chan EntryID
+    resume     chan EntryID
+    reschedule chan entryReschedule
+    done       *entryQueue
+    depend     chan entryDependency
+    trigger    chan entryTrigger
+    succeeded  *entryQueue
+    snapshot   chan chan []Entry
+    lookup     chan entryLookup
+    running    bool
+    logger     *glog.Helper
+    runningMu  sync.Mutex
+    location   *time.Location
+    parser     ScheduleParser
+    nextID     EntryID
+    jobWaiter  sync.WaitGroup
+    jobCtx     context.Context
+    jobCancel  context.CancelFunc
+    store      JobStore
+    saver      *entrySaver
+    stored     map[string]time.Time // 具名条目已持久化的上次运行时间，调度程序启动时从任务存储加载。
+    misfire    MisfirePolicy
+    dst        DSTPolicy
+    clock      Clock
+    pool       *workerPool
+
+    historyLimit int
+    onJobStart   func(JobRun)
+    onJobFinish  func(JobRun)
+    onJobSkipped func(JobRun)
 }
 
 // ScheduleParser 返回 Schedule 的调度规范解析器的接口。
@@ -37,6 +59,18 @@ type Job interface {
     Run()
 }
 
+// ContextJob 可感知上下文的 cron 任务接口。
+// 传入的上下文在 Cron.Stop 时取消，长时间运行的任务应据此及时退出。
+type ContextJob interface {
+    RunContext(ctx context.Context)
+}
+
+// ErrorJob 可返回错误的 cron 任务接口。
+// 返回的错误会记录到运行历史，并可被 Retry 等包装器处理。
+type ErrorJob interface {
+    RunE(ctx context.Context) error
+}
+
 // Schedule 描述一个任务的工作周期。
 type Schedule interface {
     // Next 返回下一个激活时间，晚于给定时间。
@@ -44,6 +78,22 @@ type Schedule interface {
     Next(time.Time) time.Time
 }
 
+// JobInfo 描述一次任务运行，可通过 JobInfoFromContext 从任务上下文获取。
+type JobInfo struct {
+    ID        EntryID   // 条目 ID。
+    Name      string    // 条目名称。
+    Scheduled time.Time // 本次运行的调度时间，不含 Jitter 叠加的随机延迟，各实例一致。
+}
+
+// jobInfoKey 任务上下文中 JobInfo 的键。
+type jobInfoKey struct{}
+
+// JobInfoFromContext 返回 Cron 在任务上下文中携带的运行信息。
+func JobInfoFromContext(ctx context.Context) (JobInfo, bool) {
+    info, ok := ctx.Value(jobInfoKey{}).(JobInfo)
+    return info, ok
+}
+
 // EntryID 标识 Cron 实例中的条目。
 type EntryID int
 
@@ -52,25 +102,135 @@ type Entry struct {
     // ID 此条目的 cron 分配的 ID，可用于查找快照或删除它。
     ID EntryID
 
+    // Name 条目名称，跨进程重启保持稳定，用于持久化条目状态。
+    Name string
+
+    // Spec 创建此条目的调度规范，直接通过 Schedule 添加的条目为空。
+    Spec string
+
     // Schedule 运行此任务的计划。
     Schedule Schedule
 
     // Next 任务将运行的时间，或者如果 Cron 尚未启动或此条目的计划无法满足，则为零时间。
+    // 固定延迟的条目在任务运行期间也为零时间。
     Next time.Time
 
     // Prev 上次运行此作业的时间，如果从未运行，则为零时间。
     Prev time.Time
 
+    // Paused 条目是否已暂停，暂停期间 Next 为零时间。
+    Paused bool
+
     // WrappedJob 激活调度时要运行的任务。
     WrappedJob Job
 
     // Job 提交给 cron 的任务。
     Job Job
+
+    // DependsOn 此条目依赖的条目，它们全部成功运行后此条目运行一次。
+    DependsOn []EntryID
+
+    // Dependents 依赖此条目的条目。
+    Dependents []EntryID
+
+    // History 最近若干次运行的记录，按记录时间从早到晚排列，仅在快照中填充。
+    History []JobRun
+
+    // Running 正在运行的任务数，仅在快照中填充。
+    Running int
+
+    // tracker 记录运行历史并触发生命周期钩子。
+    tracker *jobTracker
+
+    // wrappers 应用于条目调度的装饰器，更换调度时重新应用。
+    wrappers []ScheduleWrapper
+
+    // scheduled Next 对应的未叠加随机延迟的调度时间。
+    scheduled time.Time
+
+    // index 条目在调度堆中的位置。
+    index int
+
+    // slots 限制条目同时运行的任务数，为 nil 时不限制。
+    slots chan struct{}
+
+    // runs 条目已激活的次数，用于判断 ExpiringSchedule 是否过期。
+    runs int
+
+    // satisfied 自上次由依赖触发以来已成功运行的上游条目。
+    satisfied map[EntryID]struct{}
+}
+
+// entryTrigger 立即运行条目的请求。
+type entryTrigger struct {
+    id    EntryID
+    reply chan error
+}
+
+// entryReschedule 更换条目调度的请求。
+type entryReschedule struct {
+    id    EntryID
+    spec  string
+    reply chan error
+}
+
+// entryLookup 查找单个条目快照的请求，id 为 0 时按 name 查找。
+type entryLookup struct {
+    id    EntryID
+    name  string
+    reply chan Entry
+}
+
+// entryEvent 任务 goroutine 交给调度程序处理的一次运行的结果。
+type entryEvent struct {
+    id        EntryID
+    scheduled time.Time // 本次运行的调度时间。
+}
+
+// entryQueue 任务 goroutine 交给调度程序处理的事件队列。
+// 入队从不阻塞：任务结束前仍占用 worker 池的空位，不能等待调度程序。
+type entryQueue struct {
+    mu     sync.Mutex
+    events []entryEvent
+    ready  chan struct{}
+}
+
+// newEntryQueue 新建一个空队列。
+func newEntryQueue() *entryQueue {
+    return &entryQueue{ready: make(chan struct{}, 1)}
+}
+
+// push 将事件加入队列并通知调度程序。
+func (q *entryQueue) push(ev entryEvent) {
+    q.mu.Lock()
+    q.events = append(q.events, ev)
+    q.mu.Unlock()
+    select {
+    case q.ready <- struct{}{}:
+    default:
+    }
+}
+
+// drain 取出队列中的全部事件。
+func (q *entryQueue) drain() []entryEvent {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    events := q.events
+    q.events = nil
+    return events
 }
 
 // Valid 如果这不是零条目，则返回 true。
 func (e Entry) Valid() bool { return e.ID != 0 }
 
+// wrapSchedule 用条目的调度装饰器装饰指定的调度。
+func (e *Entry) wrapSchedule(schedule Schedule) Schedule {
+    for i := range e.wrappers {
+        schedule = e.wrappers[len(e.wrappers)-i-1](schedule)
+    }
+    return schedule
+}
+
 // byTime 是按时间对条目数组进行排序的包装器（最后时间为零）。
 type byTime []*Entry
 
@@ -108,17 +268,27 @@ func (s byTime) Less(i, j int) bool {
 // 请参阅“cron.With*”以修改默认行为。
 func New(opts ...Option) *Cron {
     c := &Cron{
-        entries:   nil,
-        chain:     NewChain(),
-        add:       make(chan *Entry),
-        stop:      make(chan struct{}),
-        snapshot:  make(chan chan []Entry),
-        remove:    make(chan EntryID),
-        running:   false,
-        runningMu: sync.Mutex{},
-        logger:    glog.NewHelper(glog.GetLogger()),
-        location:  time.Local,
-        parser:    standardParser,
+        chain:      NewChain(),
+        add:        make(chan *Entry),
+        stop:       make(chan struct{}),
+        snapshot:   make(chan chan []Entry),
+        lookup:     make(chan entryLookup),
+        remove:     make(chan EntryID),
+        pause:      make(chan EntryID),
+        resume:     make(chan EntryID),
+        reschedule: make(chan entryReschedule),
+        done:       newEntryQueue(),
+        depend:     make(chan entryDependency),
+        trigger:    make(chan entryTrigger),
+        succeeded:  newEntryQueue(),
+        running:    false,
+        runningMu:  sync.Mutex{},
+        logger:     glog.NewHelper(glog.GetLogger()),
+        location:   time.Local,
+        parser:     standardParser,
+        clock:      realClock{},
+
+        historyLimit: defaultHistoryLimit,
     }
     for _, opt := range opts {
         opt(c)
@@ -131,38 +301,152 @@ type FuncJob func()
 
 func (f FuncJob) Run() { f() }
 
+// FuncContextJob 是将 func(context.Context) 转换为 cron.Job 和 cron.ContextJob 的包装器。
+type FuncContextJob func(ctx context.Context)
+
+// Run 使用 context.Background() 运行任务。
+func (f FuncContextJob) Run() { f(context.Background()) }
+
+// RunContext 使用指定的上下文运行任务。
+func (f FuncContextJob) RunContext(ctx context.Context) { f(ctx) }
+
+// FuncErrorJob 是将 func(context.Context) error 转换为 cron.Job、cron.ContextJob 和 cron.ErrorJob 的包装器。
+type FuncErrorJob func(ctx context.Context) error
+
+// Run 使用 context.Background() 运行任务并忽略错误。
+func (f FuncErrorJob) Run() { _ = f(context.Background()) }
+
+// RunContext 使用指定的上下文运行任务并忽略错误。
+func (f FuncErrorJob) RunContext(ctx context.Context) { _ = f(ctx) }
+
+// RunE 使用指定的上下文运行任务。
+func (f FuncErrorJob) RunE(ctx context.Context) error { return f(ctx) }
+
+// errorJob 将 ErrorJob 适配为 Job。
+type errorJob struct {
+    ErrorJob
+}
+
+// Run 使用 context.Background() 运行任务并忽略错误。
+func (j errorJob) Run() { _ = j.RunE(context.Background()) }
+
+// RunContext 使用指定的上下文运行任务并忽略错误。
+func (j errorJob) RunContext(ctx context.Context) { _ = j.RunE(ctx) }
+
+// jobError 将 Job 适配为 ErrorJob，不返回错误的任务总是返回 nil。
+type jobError struct {
+    ContextJob
+}
+
+// RunE 使用指定的上下文运行任务。
+func (j jobError) RunE(ctx context.Context) error {
+    j.RunContext(ctx)
+    return nil
+}
+
+// toErrorJob 返回 j 的 ErrorJob 形式，已实现 ErrorJob 的任务原样返回。
+func toErrorJob(j Job) ErrorJob {
+    if ej, ok := j.(ErrorJob); ok {
+        return ej
+    }
+    return jobError{toContextJob(j)}
+}
+
+// contextJob 将 ContextJob 适配为 Job。
+type contextJob struct {
+    ContextJob
+}
+
+// Run 使用 context.Background() 运行任务。
+func (j contextJob) Run() { j.RunContext(context.Background()) }
+
+// jobContext 将 Job 适配为 ContextJob，不感知上下文的任务将忽略传入的上下文。
+type jobContext struct {
+    Job
+}
+
+// RunContext 忽略上下文运行任务。
+func (j jobContext) RunContext(context.Context) { j.Run() }
+
+// toContextJob 返回 j 的 ContextJob 形式，已实现 ContextJob 的任务原样返回。
+func toContextJob(j Job) ContextJob {
+    if cj, ok := j.(ContextJob); ok {
+        return cj
+    }
+    return jobContext{j}
+}
+
 // AddFunc 向 Cron 添加一个函数，按给定的时间表运行。
 // 使用此 Cron 实例的时区作为默认值解析规范。
 // 返回一个不透明的 ID，以后可以使用它来移除它。
-func (c *Cron) AddFunc(spec string, cmd func()) (EntryID, error) {
-    return c.AddJob(spec, FuncJob(cmd))
+func (c *Cron) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+    return c.AddJob(spec, FuncJob(cmd), opts...)
 }
 
 // AddJob 添加任务到 Cron 以按给定的时间表运行。
 // 使用此 Cron 实例的时区作为默认值来解析规范。
 // 返回一个 ID，可用于稍后将其删除。
-func (c *Cron) AddJob(spec string, cmd Job) (EntryID, error) {
-    schedule, err := c.parser.Parse(spec)
+func (c *Cron) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+    var named Entry
+    for _, opt := range opts {
+        opt(&named)
+    }
+    schedule, err := c.parse(spec, named.Name)
     if err != nil {
         return 0, err
     }
-    return c.Schedule(schedule, cmd), nil
+    return c.Schedule(schedule, cmd, append([]EntryOption{withSpec(spec)}, opts...)...), nil
+}
+
+// AddContextFunc 向 Cron 添加一个可感知上下文的函数，按给定的时间表运行。
+// 函数收到的上下文在 Cron.Stop 时取消。
+func (c *Cron) AddContextFunc(spec string, cmd func(ctx context.Context), opts ...EntryOption) (EntryID, error) {
+    return c.AddJob(spec, FuncContextJob(cmd), opts...)
+}
+
+// AddContextJob 添加可感知上下文的任务到 Cron 以按给定的时间表运行。
+// 任务收到的上下文在 Cron.Stop 时取消。
+func (c *Cron) AddContextJob(spec string, cmd ContextJob, opts ...EntryOption) (EntryID, error) {
+    if j, ok := cmd.(Job); ok {
+        return c.AddJob(spec, j, opts...)
+    }
+    return c.AddJob(spec, contextJob{cmd}, opts...)
+}
+
+// AddErrorFunc 向 Cron 添加一个可返回错误的函数，按给定的时间表运行。
+// 返回的错误会记录到运行历史，并可被 Retry 等包装器处理。
+func (c *Cron) AddErrorFunc(spec string, cmd func(ctx context.Context) error, opts ...EntryOption) (EntryID, error) {
+    return c.AddJob(spec, FuncErrorJob(cmd), opts...)
+}
+
+// AddErrorJob 添加可返回错误的任务到 Cron 以按给定的时间表运行。
+// 返回的错误会记录到运行历史，并可被 Retry 等包装器处理。
+func (c *Cron) AddErrorJob(spec string, cmd ErrorJob, opts ...EntryOption) (EntryID, error) {
+    if j, ok := cmd.(Job); ok {
+        return c.AddJob(spec, j, opts...)
+    }
+    return c.AddJob(spec, errorJob{cmd}, opts...)
 }
 
 // Schedule 将任务添加至 Cron 按指定的时间表运行。
 // 该任务使用配置的 Chain 进行包装。
-func (c *Cron) Schedule(schedule Schedule, cmd Job) EntryID {
+func (c *Cron) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
     c.runningMu.Lock()
     defer c.runningMu.Unlock()
     c.nextID++
     entry := &Entry{
         ID:         c.nextID,
         Schedule:   schedule,
-        WrappedJob: c.chain.Then(cmd),
+        WrappedJob: c.chain.Then(trackJob(cmd)),
         Job:        cmd,
     }
+    entry.tracker = &jobTracker{c: c, history: &jobHistory{limit: c.historyLimit}}
+    for _, opt := range opts {
+        opt(entry)
+    }
+    entry.Schedule = entry.wrapSchedule(schedule)
     if !c.running {
-        c.entries = append(c.entries, entry)
+        c.entries.push(entry)
     } else {
         c.add <- entry
     }
@@ -186,14 +470,103 @@ func (c *Cron) Location() *time.Location {
     return c.location
 }
 
-// Entry 返回给定条目的快照，如果找不到，则返回 nil。
+// Entry 返回给定条目的快照，如果找不到，则返回零条目。
 func (c *Cron) Entry(id EntryID) Entry {
-    for _, entry := range c.Entries() {
-        if id == entry.ID {
-            return entry
-        }
+    if id == 0 {
+        return Entry{}
+    }
+    return c.lookupEntry(entryLookup{id: id})
+}
+
+// EntryByName 返回指定名称条目的快照，有多个同名条目时返回 ID 最小的条目，如果找不到，则返回零条目。
+func (c *Cron) EntryByName(name string) Entry {
+    return c.lookupEntry(entryLookup{name: name})
+}
+
+// lookupEntry 在调度程序运行时通过其查找条目，否则直接查找。
+func (c *Cron) lookupEntry(req entryLookup) Entry {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    if c.running {
+        req.reply = make(chan Entry, 1)
+        c.lookup <- req
+        return <-req.reply
+    }
+    return c.findEntry(req)
+}
+
+// Pause 暂停条目，暂停期间条目保留调度但不会运行。
+func (c *Cron) Pause(id EntryID) {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    if c.running {
+        c.pause <- id
+    } else {
+        c.pauseEntry(id)
+    }
+}
+
+// Resume 恢复已暂停的条目，从当前时间起按原调度继续运行。
+func (c *Cron) Resume(id EntryID) {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    if c.running {
+        c.resume <- id
+    } else {
+        c.resumeEntry(id, c.now())
+    }
+}
+
+// Reschedule 原子地将条目的调度替换为 spec 描述的新调度，条目的任务和运行状态保持不变。
+// 使用此 Cron 实例的解析器解析规范，规范无效时返回错误；条目不存在时什么也不做。
+func (c *Cron) Reschedule(id EntryID, spec string) error {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    req := entryReschedule{id: id, spec: spec}
+    if c.running {
+        req.reply = make(chan error, 1)
+        c.reschedule <- req
+        return <-req.reply
     }
-    return Entry{}
+    return c.rescheduleEntry(req, c.now())
+}
+
+// Trigger 立即在调度之外运行一次条目的任务，任务同样经过 WrappedJob 的包装链，不影响条目的下一次调度时间。
+// 这次运行同样计入 At、Bounded 等计划的激活次数，次数用尽时条目随即被移除。
+// Cron 未运行或条目不存在时返回错误。
+func (c *Cron) Trigger(id EntryID) error {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    if !c.running {
+        return fmt.Errorf("cron is not running")
+    }
+    req := entryTrigger{id: id, reply: make(chan error, 1)}
+    c.trigger <- req
+    return <-req.reply
+}
+
+// IsRunning 如果 cron 调度程序正在运行，则返回 true。
+func (c *Cron) IsRunning() bool {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    return c.running
+}
+
+// parse 解析规范，解析器支持 H 哈希字段时以条目名称为 key，并为未设置夏令时策略的计划应用 Cron 的策略。
+func (c *Cron) parse(spec, name string) (schedule Schedule, err error) {
+    if p, ok := c.parser.(HashScheduleParser); ok {
+        schedule, err = p.ParseHash(spec, name)
+    } else {
+        schedule, err = c.parser.Parse(spec)
+    }
+    inner := schedule
+    if s, ok := inner.(CalendarSchedule); ok {
+        inner = s.Schedule
+    }
+    if s, ok := inner.(*SpecSchedule); ok && s.DST == DSTDefault {
+        s.DST = c.dst
+    }
+    return schedule, err
 }
 
 // Remove 移除将要运行的条目。
@@ -215,6 +588,13 @@ func (c *Cron) Start() {
         return
     }
     c.running = true
+    c.jobCtx, c.jobCancel = context.WithCancel(context.Background())
+    if c.pool != nil {
+        c.pool.start()
+    }
+    if c.store != nil {
+        c.saver = startEntrySaver(c.store, c.logger)
+    }
     go c.run()
 }
 
@@ -226,6 +606,13 @@ func (c *Cron) Run() {
         return
     }
     c.running = true
+    c.jobCtx, c.jobCancel = context.WithCancel(context.Background())
+    if c.pool != nil {
+        c.pool.start()
+    }
+    if c.store != nil {
+        c.saver = startEntrySaver(c.store, c.logger)
+    }
     c.runningMu.Unlock()
     c.run()
 }
@@ -236,51 +623,97 @@ func (c *Cron) run() {
 
     // 计算出每个条目的下一个激活时间。
     now := c.now()
-    for _, entry := range c.entries {
-        entry.Next = entry.Schedule.Next(now)
+    for _, entry := range c.entries.items {
+        if entry.Paused {
+            continue
+        }
+        c.scheduleNext(entry, now)
         // c.logger.Infow(glog.DefaultMessageKey, "Cron", "action", "schedule", "now", now, "entry", entry.ID, "next", entry.Next)
     }
+    c.entries.init()
 
-    for {
-        // 确定要运行的下一个条目。
-        sort.Sort(byTime(c.entries))
+    // 恢复持久化的条目状态，并按错过策略补偿停机期间错过的运行。
+    c.restoreEntries(now)
+    c.removeExpired(now)
 
-        var timer *time.Timer
-        if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
+    for {
+        // 确定要运行的下一个条目，即堆顶的条目。
+        var timer Timer
+        if next := c.entries.peek(); next == nil || next.Next.IsZero() {
             // 如果还没有条目，只需休眠 - 它仍会处理新条目并停止请求。
-            timer = time.NewTimer(100000 * time.Hour)
+            timer = c.clock.NewTimer(100000 * time.Hour)
         } else {
-            timer = time.NewTimer(c.entries[0].Next.Sub(now))
+            timer = c.clock.NewTimer(next.Next.Sub(now))
         }
 
         for {
             select {
-            case now = <-timer.C:
+            case now = <-timer.C():
                 now = now.In(c.location)
                 // c.logger.Infow(glog.DefaultMessageKey, "Cron", "action", "wake", "now", now)
 
                 // 运行下一次小于现在的每个条目
-                for _, e := range c.entries {
-                    if e.Next.After(now) || e.Next.IsZero() {
-                        break
-                    }
-                    c.startJob(e.WrappedJob)
+                for _, e := range c.entries.popDue(now) {
+                    async := c.startJob(e, e.scheduled)
                     e.Prev = e.Next
-                    e.Next = e.Schedule.Next(now)
+                    switch {
+                    case !fixedDelay(e.Schedule):
+                        c.scheduleNext(e, now)
+                    case async:
+                        // 固定延迟的条目等任务结束后再调度。
+                        e.Next = time.Time{}
+                    default:
+                        c.scheduleNext(e, c.now())
+                    }
+                    c.entries.pushBack(e)
+                    c.saveEntry(e)
+                    if expired(e.Schedule, now, e.runs) {
+                        c.removeEntry(e.ID)
+                    }
                     // c.logger.Infow(glog.DefaultMessageKey, "Cron", "action", "run", "now", now, "entry", e.ID, "next", e.Next)
                 }
 
             case newEntry := <-c.add:
                 timer.Stop()
                 now = c.now()
-                newEntry.Next = newEntry.Schedule.Next(now)
-                c.entries = append(c.entries, newEntry)
+                c.scheduleNext(newEntry, now)
+                c.restoreEntry(newEntry, now)
+                if !expired(newEntry.Schedule, now, newEntry.runs) {
+                    c.entries.push(newEntry)
+                }
                 // c.logger.Infow(glog.DefaultMessageKey, "Cron", "action", "added", "now", now, "entry", newEntry.ID, "next", newEntry.Next)
 
+            case <-c.done.ready:
+                timer.Stop()
+                now = c.now()
+                for _, ev := range c.done.drain() {
+                    c.finishEntry(ev.id, now)
+                }
+
+            case <-c.succeeded.ready:
+                timer.Stop()
+                now = c.now()
+                for _, ev := range c.succeeded.drain() {
+                    c.runDependents(ev.id, ev.scheduled, now)
+                }
+
+            case req := <-c.trigger:
+                timer.Stop()
+                now = c.now()
+                req.reply <- c.triggerEntry(req.id, now)
+
+            case req := <-c.depend:
+                req.reply <- c.dependEntry(req.id, req.parents)
+                continue
+
             case replyChan := <-c.snapshot:
                 replyChan <- c.entrySnapshot()
                 continue
 
+            case req := <-c.lookup:
+                req.reply <- c.findEntry(req)
+                continue
+
             case <-c.stop:
                 timer.Stop()
                 // c.logger.Info("stop")
@@ -291,6 +724,21 @@ func (c *Cron) run() {
                 now = c.now()
                 c.removeEntry(id)
                 // c.logger.Infow(glog.DefaultMessageKey, "Cron", "action", "removed", "entry", id)
+
+            case id := <-c.pause:
+                timer.Stop()
+                now = c.now()
+                c.pauseEntry(id)
+
+            case id := <-c.resume:
+                timer.Stop()
+                now = c.now()
+                c.resumeEntry(id, now)
+
+            case req := <-c.reschedule:
+                timer.Stop()
+                now = c.now()
+                req.reply <- c.rescheduleEntry(req, now)
             }
 
             break
@@ -298,28 +746,117 @@ func (c *Cron) run() {
     }
 }
 
-// startJob 在新的 goroutine 中运行指定的任务。
-func (c *Cron) startJob(j Job) {
+// startJob 在新的 goroutine 或 worker 池中运行条目的任务，任务收到的上下文在 Cron.Stop 时取消，并携带本次运行信息。
+// 条目同时运行的任务数达到上限，或 worker 池已满且溢出策略为丢弃时，本次运行被跳过。
+// 任务在后台运行时返回 true，此时固定延迟的条目会在任务结束后通知调度程序；任务被跳过或已直接运行完成时返回 false。
+// 有下游条目的任务成功运行后，调度程序会运行依赖已满足的下游条目；被包装器跳过的运行不算成功。
+func (c *Cron) startJob(e *Entry, scheduled time.Time) bool {
+    e.runs++
+    state := new(jobRunState)
+    ctx := context.WithValue(c.jobCtx, jobInfoKey{}, JobInfo{ID: e.ID, Name: e.Name, Scheduled: scheduled})
+    ctx = context.WithValue(ctx, jobTrackerKey{}, e.tracker)
+    ctx = context.WithValue(ctx, jobRunKey{}, state)
+
+    slots := e.slots
+    if slots != nil {
+        select {
+        case slots <- struct{}{}:
+        default:
+            c.logger.Warnw(glog.DefaultMessageKey, "Cron", "action", "limit", "entry", e.ID, "max", cap(slots))
+            reportSkipped(ctx)
+            return false
+        }
+    }
+    release := func() {
+        if slots != nil {
+            <-slots
+        }
+    }
+
     c.jobWaiter.Add(1)
-    go func() {
+    var (
+        j         = e.WrappedJob
+        id        = e.ID
+        notify    = fixedDelay(e.Schedule)
+        fanOut    = len(e.Dependents) > 0
+        succeeded bool
+    )
+    run := func() {
         defer c.jobWaiter.Done()
-        j.Run()
-    }()
+        defer release()
+        succeeded = toErrorJob(j).RunE(ctx) == nil && !state.skipped.Load()
+        if fanOut && succeeded {
+            c.succeeded.push(entryEvent{id: id, scheduled: scheduled})
+        }
+        if notify {
+            c.done.push(entryEvent{id: id, scheduled: scheduled})
+        }
+    }
+    if c.pool == nil {
+        go run()
+        return true
+    }
+    if c.pool.trySubmit(run) {
+        return true
+    }
+
+    c.logger.Warnw(glog.DefaultMessageKey, "Cron", "action", "saturated", "entry", e.ID, "overflow", c.pool.overflow)
+    switch c.pool.overflow {
+    case OverflowRunInline:
+        // 在调度 goroutine 中运行，不能再通知自己。
+        notify, fanOut = false, false
+        run()
+        if len(e.Dependents) > 0 && succeeded {
+            c.runDependents(id, scheduled, c.now())
+        }
+        return false
+    case OverflowBlock:
+        c.pool.enqueue(ctx, run, func() {
+            c.jobWaiter.Done()
+            release()
+            reportSkipped(ctx)
+        })
+        return true
+    }
+    c.jobWaiter.Done()
+    release()
+    reportSkipped(ctx)
+    return false
+}
+
+// scheduleNext 计算条目在 t 之后的下一次激活时间，并记录其未叠加随机延迟的调度时间。
+func (c *Cron) scheduleNext(e *Entry, t time.Time) {
+    e.Next = e.Schedule.Next(t)
+    e.scheduled = e.Next
+    if base, ok := withoutJitter(e.Schedule); ok && !e.Next.IsZero() {
+        e.scheduled = base.Next(t)
+    }
 }
 
 // now 从 c location 获取当前时间。
 func (c *Cron) now() time.Time {
-    return time.Now().In(c.location)
+    return c.clock.Now().In(c.location)
 }
 
 // Stop 如果 cron 调度程序正在运行，则停止它； 否则它什么也不做。
+// 正在运行的 ContextJob 收到的上下文会被取消，以便其尽快退出。
+// 配置了任务存储时，返回前保存尚未保存的条目状态。
 // 传入一个上下文，以便调用者可以等待正在运行的作业完成。
 func (c *Cron) Stop(ctx context.Context) context.Context {
     c.runningMu.Lock()
     defer c.runningMu.Unlock()
     if c.running {
+        // 先取消任务的上下文，使阻塞在已满 worker 池上的调度得以退出。
+        c.jobCancel()
         c.stop <- struct{}{}
         c.running = false
+        if c.pool != nil {
+            c.pool.stop()
+        }
+        if c.saver != nil {
+            c.saver.stop()
+            c.saver = nil
+        }
     }
     ctx, cancel := context.WithCancel(ctx)
     go func() {
@@ -330,22 +867,124 @@ func (c *Cron) Stop(ctx context.Context) context.Context {
     return ctx
 }
 
-// entrySnapshot 返回当前 cron 条目列表的副本。
+// entrySnapshot 返回当前 cron 条目列表的副本，按下一次运行时间排列。
 func (c *Cron) entrySnapshot() []Entry {
-    var entries = make([]Entry, len(c.entries))
-    for i, e := range c.entries {
-        entries[i] = *e
+    var entries = make([]Entry, c.entries.Len())
+    for i, e := range c.entries.sorted() {
+        entries[i] = snapshotEntry(e)
     }
     return entries
 }
 
+// snapshotEntry 返回条目的副本，并填充运行历史和正在运行的任务数。
+func snapshotEntry(e *Entry) Entry {
+    entry := *e
+    entry.History = e.tracker.history.list()
+    entry.Running = int(e.tracker.active.Load())
+    return entry
+}
+
+// findEntry 返回请求的条目快照，如果找不到，则返回零条目。
+func (c *Cron) findEntry(req entryLookup) Entry {
+    e := c.entry(req.id)
+    if req.id == 0 {
+        for _, item := range c.entries.items {
+            if item.Name == req.name && (e == nil || item.ID < e.ID) {
+                e = item
+            }
+        }
+    }
+    if e == nil {
+        return Entry{}
+    }
+    return snapshotEntry(e)
+}
+
+// entry 返回指定 ID 的条目，如果找不到，则返回 nil。
+func (c *Cron) entry(id EntryID) *Entry {
+    return c.entries.get(id)
+}
+
+// pauseEntry 暂停当前 cron 指定的条目。
+func (c *Cron) pauseEntry(id EntryID) {
+    if e := c.entry(id); e != nil {
+        e.Paused = true
+        e.Next = time.Time{}
+        c.entries.fix(e)
+    }
+}
+
+// resumeEntry 恢复当前 cron 指定的条目。
+func (c *Cron) resumeEntry(id EntryID, now time.Time) {
+    if e := c.entry(id); e != nil && e.Paused {
+        e.Paused = false
+        c.scheduleNext(e, now)
+        c.entries.fix(e)
+    }
+}
+
+// rescheduleEntry 以条目名称为哈希键解析规范，并替换当前 cron 指定条目的调度，条目不存在时什么也不做。
+func (c *Cron) rescheduleEntry(req entryReschedule, now time.Time) error {
+    e := c.entry(req.id)
+    if e == nil {
+        return nil
+    }
+    schedule, err := c.parse(req.spec, e.Name)
+    if err != nil {
+        return err
+    }
+    e.Schedule = e.wrapSchedule(schedule)
+    e.Spec = req.spec
+    if !e.Paused {
+        c.scheduleNext(e, now)
+        c.entries.fix(e)
+    }
+    c.saveEntry(e)
+    return nil
+}
+
+// triggerEntry 立即运行当前 cron 指定条目的任务。
+func (c *Cron) triggerEntry(id EntryID, now time.Time) error {
+    e := c.entry(id)
+    if e == nil {
+        return fmt.Errorf("entry %d not found", id)
+    }
+    c.startJob(e, now)
+    e.Prev = now
+    c.saveEntry(e)
+    if expired(e.Schedule, now, e.runs) {
+        c.removeEntry(id)
+    }
+    return nil
+}
+
+// finishEntry 在固定延迟条目的任务结束后，从结束时间起调度其下一次运行。
+func (c *Cron) finishEntry(id EntryID, now time.Time) {
+    e := c.entry(id)
+    if e == nil || e.Paused || !e.Next.IsZero() {
+        return
+    }
+    c.scheduleNext(e, now)
+    c.entries.fix(e)
+}
+
+// removeExpired 移除计划已过期的条目。
+func (c *Cron) removeExpired(now time.Time) {
+    var ids []EntryID
+    for _, e := range c.entries.items {
+        if !e.Paused && expired(e.Schedule, now, e.runs) {
+            ids = append(ids, e.ID)
+        }
+    }
+    for _, id := range ids {
+        c.removeEntry(id)
+    }
+}
+
 // removeEntry 移除当前 cron 指定的条目。
 func (c *Cron) removeEntry(id EntryID) {
-    var entries []*Entry
-    for _, e := range c.entries {
-        if e.ID != id {
-            entries = append(entries, e)
-        }
+    if e := c.entry(id); e != nil {
+        c.unlinkEntry(e)
     }
-    c.entries = entries
+    c.entries.remove(id)
 }
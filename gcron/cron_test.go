@@ -608,6 +608,159 @@ func TestStopAndWait(t *testing.T) {
     })
 }
 
+func TestContextJobCancelledOnStop(t *testing.T) {
+    started := make(chan struct{})
+    cancelled := make(chan struct{})
+
+    cron := newWithSeconds()
+    cron.AddContextFunc("* * * * * ?", func(ctx context.Context) {
+        select {
+        case started <- struct{}{}:
+        default:
+            return
+        }
+        <-ctx.Done()
+        close(cancelled)
+    })
+    cron.Start()
+
+    select {
+    case <-started:
+    case <-time.After(OneSecond):
+        t.Fatal("expected job runs")
+    }
+
+    ctx := cron.Stop(context.Background())
+    select {
+    case <-cancelled:
+    case <-time.After(time.Second):
+        t.Fatal("expected job context to be cancelled on Stop")
+    }
+    select {
+    case <-ctx.Done():
+    case <-time.After(time.Second):
+        t.Error("expected Stop context done after job exits")
+    }
+}
+
+type testContextJob struct {
+    wg *sync.WaitGroup
+}
+
+func (t testContextJob) RunContext(ctx context.Context) {
+    if ctx.Err() == nil {
+        t.wg.Done()
+    }
+}
+
+func TestAddContextJob(t *testing.T) {
+    wg := &sync.WaitGroup{}
+    wg.Add(1)
+
+    cron := newWithSeconds()
+    id, err := cron.AddContextJob("* * * * * ?", testContextJob{wg})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, ok := cron.Entry(id).Job.(ContextJob); !ok {
+        t.Error("expected entry job to keep the context job")
+    }
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    select {
+    case <-time.After(OneSecond):
+        t.Fatal("expected job runs")
+    case <-wait(wg):
+    }
+}
+
+func TestEntryByName(t *testing.T) {
+    cron := newWithSeconds()
+    id, _ := cron.AddFunc("* * * * * ?", func() {}, WithEntryName("report"))
+    cron.AddFunc("* * * * * ?", func() {}, WithEntryName("cleanup"))
+
+    if entry := cron.EntryByName("report"); entry.ID != id || entry.Spec != "* * * * * ?" {
+        t.Errorf("unexpected entry: %+v", entry)
+    }
+    cron.Start()
+    defer cron.Stop(context.Background())
+    if entry := cron.EntryByName("report"); entry.ID != id {
+        t.Errorf("unexpected entry while running: %+v", entry)
+    }
+    if cron.EntryByName("missing").Valid() {
+        t.Error("expected zero entry for unknown name")
+    }
+    cron.AddFunc("* * * * * ?", func() {}, WithEntryName("report"))
+    if entry := cron.EntryByName("report"); entry.ID != id {
+        t.Errorf("expected first entry for duplicate name, got %+v", entry)
+    }
+    if entry := cron.Entry(id); entry.Name != "report" {
+        t.Errorf("unexpected entry by id: %+v", entry)
+    }
+    if cron.Entry(100).Valid() {
+        t.Error("expected zero entry for unknown id")
+    }
+}
+
+func TestPauseAndResume(t *testing.T) {
+    var calls int64
+    cron := newWithSeconds()
+    id, _ := cron.AddFunc("* * * * * ?", func() { atomic.AddInt64(&calls, 1) })
+    cron.Pause(id)
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    <-time.After(OneSecond)
+    if c := atomic.LoadInt64(&calls); c != 0 {
+        t.Errorf("expected paused entry not run, got %d", c)
+    }
+    if entry := cron.Entry(id); !entry.Paused || !entry.Next.IsZero() {
+        t.Errorf("expected paused entry without next time, got %+v", entry)
+    }
+
+    cron.Resume(id)
+    if entry := cron.Entry(id); entry.Paused || entry.Next.IsZero() {
+        t.Errorf("expected resumed entry to be scheduled, got %+v", entry)
+    }
+    <-time.After(OneSecond)
+    if c := atomic.LoadInt64(&calls); c != 1 {
+        t.Errorf("expected resumed entry run once, got %d", c)
+    }
+
+    cron.Pause(id)
+    <-time.After(OneSecond)
+    if c := atomic.LoadInt64(&calls); c != 1 {
+        t.Errorf("expected paused entry not run again, got %d", c)
+    }
+}
+
+func TestReschedule(t *testing.T) {
+    wg := &sync.WaitGroup{}
+    wg.Add(1)
+
+    cron := newWithSeconds()
+    id, _ := cron.AddFunc("0 0 0 1 1 ?", func() { wg.Done() })
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    if err := cron.Reschedule(id, "not a spec"); err == nil {
+        t.Error("expected an error with invalid spec")
+    }
+    if err := cron.Reschedule(id, "* * * * * ?"); err != nil {
+        t.Fatal(err)
+    }
+    if entry := cron.Entry(id); entry.Spec != "* * * * * ?" {
+        t.Errorf("expected spec updated, got %q", entry.Spec)
+    }
+
+    select {
+    case <-time.After(OneSecond):
+        t.Error("expected rescheduled job runs")
+    case <-wait(wg):
+    }
+}
+
 func TestMultiThreadedStartAndStop(t *testing.T) {
     cron := New()
     go cron.Run()
@@ -0,0 +1,195 @@
+package gcron
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "sync"
+    "time"
+)
+
+// CrontabLine crontab 文件中的一行，由调度规范和任务名称组成。
+type CrontabLine struct {
+    Line int    // 行号，从 1 开始。
+    Spec string // 调度规范，可带 TZ=、CRON_TZ= 前缀。
+    Name string // 任务名称，同时用作条目名称。
+}
+
+// ParseCrontab 解析 crontab 格式的内容，每行为“调度规范 任务名称”，空行和以 # 开头的行被忽略。
+//
+// 例子
+//
+//	# 每天 02:00 清理
+//	0 2 * * * cleanup
+//	TZ=Asia/Shanghai 0 9 * * 1-5 report
+//	@every 10m poll
+func ParseCrontab(r io.Reader) ([]CrontabLine, error) {
+    var (
+        lines   []CrontabLine
+        names   = map[string]int{}
+        scanner = bufio.NewScanner(r)
+    )
+    for n := 1; scanner.Scan(); n++ {
+        text := strings.TrimSpace(scanner.Text())
+        if text == "" || strings.HasPrefix(text, "#") {
+            continue
+        }
+        i := strings.LastIndexAny(text, " \t")
+        if i < 0 {
+            return nil, fmt.Errorf("crontab line %d: missing job name: %s", n, text)
+        }
+        line := CrontabLine{Line: n, Spec: strings.TrimSpace(text[:i]), Name: text[i+1:]}
+        if prev, ok := names[line.Name]; ok {
+            return nil, fmt.Errorf("crontab line %d: duplicate job name %s, first defined on line %d", n, line.Name, prev)
+        }
+        names[line.Name] = n
+        lines = append(lines, line)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+    return lines, nil
+}
+
+// Loader 从 crontab 文件加载条目，并在文件变化时将差异应用到 Cron。
+// 文件中的任务名称映射到通过 Register 注册的任务，Loader 只管理由其添加的条目。
+type Loader struct {
+    c    *Cron
+    path string
+
+    mu      sync.Mutex
+    jobs    map[string]loaderJob
+    entries map[string]loadedEntry
+    modTime time.Time
+    size    int64
+}
+
+// loaderJob 注册到 Loader 的任务。
+type loaderJob struct {
+    job  Job
+    opts []EntryOption
+}
+
+// loadedEntry Loader 添加的条目。
+type loadedEntry struct {
+    id   EntryID
+    spec string
+}
+
+// NewLoader 新建一个从 path 加载条目到 c 的 Loader。
+func NewLoader(c *Cron, path string) *Loader {
+    return &Loader{
+        c:       c,
+        path:    path,
+        jobs:    make(map[string]loaderJob),
+        entries: make(map[string]loadedEntry),
+    }
+}
+
+// Register 注册名为 name 的任务，opts 在添加条目时应用。
+func (l *Loader) Register(name string, job Job, opts ...EntryOption) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.jobs[name] = loaderJob{job: job, opts: opts}
+}
+
+// RegisterFunc 注册名为 name 的函数。
+func (l *Loader) RegisterFunc(name string, cmd func(), opts ...EntryOption) {
+    l.Register(name, FuncJob(cmd), opts...)
+}
+
+// Load 读取文件并应用差异：添加新任务、更换规范变化的任务的调度、移除文件中已删除的任务。
+// 文件中任何一行无效时返回错误，且不修改 Cron。
+func (l *Loader) Load() error {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    f, err := os.Open(l.path)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+    info, err := f.Stat()
+    if err != nil {
+        return err
+    }
+    // 即使加载失败也记录文件状态，避免 Watch 对同一份无效文件反复报错。
+    l.modTime, l.size = info.ModTime(), info.Size()
+    return l.apply(f)
+}
+
+// apply 解析内容并将差异应用到 Cron。
+func (l *Loader) apply(r io.Reader) error {
+    lines, err := ParseCrontab(r)
+    if err != nil {
+        return err
+    }
+    for _, line := range lines {
+        if _, ok := l.jobs[line.Name]; !ok {
+            return fmt.Errorf("crontab line %d: unknown job %s", line.Line, line.Name)
+        }
+        if _, err = l.c.parse(line.Spec, line.Name); err != nil {
+            return fmt.Errorf("crontab line %d: %w", line.Line, err)
+        }
+    }
+
+    seen := make(map[string]bool, len(lines))
+    for _, line := range lines {
+        seen[line.Name] = true
+        loaded, ok := l.entries[line.Name]
+        switch {
+        case !ok:
+            job := l.jobs[line.Name]
+            id, err := l.c.AddJob(line.Spec, job.job, append([]EntryOption{WithEntryName(line.Name)}, job.opts...)...)
+            if err != nil {
+                return fmt.Errorf("crontab line %d: %w", line.Line, err)
+            }
+            l.entries[line.Name] = loadedEntry{id: id, spec: line.Spec}
+        case loaded.spec != line.Spec:
+            if err = l.c.Reschedule(loaded.id, line.Spec); err != nil {
+                return fmt.Errorf("crontab line %d: %w", line.Line, err)
+            }
+            l.entries[line.Name] = loadedEntry{id: loaded.id, spec: line.Spec}
+        }
+    }
+    for name, loaded := range l.entries {
+        if !seen[name] {
+            l.c.Remove(loaded.id)
+            delete(l.entries, name)
+        }
+    }
+    return nil
+}
+
+// Watch 每隔 interval 检查一次文件，文件的修改时间或大小变化时重新加载，直到 ctx 被取消。
+// 重新加载失败时记录错误并保留原有条目。
+func (l *Loader) Watch(ctx context.Context, interval time.Duration) {
+    for {
+        timer := l.c.clock.NewTimer(interval)
+        select {
+        case <-ctx.Done():
+            timer.Stop()
+            return
+        case <-timer.C():
+        }
+        if !l.changed() {
+            continue
+        }
+        if err := l.Load(); err != nil {
+            l.c.logger.Errorf(`gcron reload crontab %s: %v`, l.path, err)
+        }
+    }
+}
+
+// changed 如果文件的修改时间或大小与上次加载时不同，则返回 true。
+func (l *Loader) changed() bool {
+    info, err := os.Stat(l.path)
+    if err != nil {
+        return false
+    }
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    return !info.ModTime().Equal(l.modTime) || info.Size() != l.size
+}
@@ -0,0 +1,121 @@
+package gcron
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestParseCrontab(t *testing.T) {
+    lines, err := ParseCrontab(strings.NewReader(`
+# nightly
+0 2 * * * cleanup
+TZ=Asia/Shanghai 0 9 * * 1-5	report
+@every 10m poll
+`))
+    if err != nil {
+        t.Fatal(err)
+    }
+    expected := []CrontabLine{
+        {3, "0 2 * * *", "cleanup"},
+        {4, "TZ=Asia/Shanghai 0 9 * * 1-5", "report"},
+        {5, "@every 10m", "poll"},
+    }
+    if len(lines) != len(expected) {
+        t.Fatalf("expected %d lines, got %v", len(expected), lines)
+    }
+    for i := range expected {
+        if lines[i] != expected[i] {
+            t.Errorf("line %d: expected %+v, got %+v", i, expected[i], lines[i])
+        }
+    }
+
+    for _, content := range []string{"cleanup", "0 2 * * * a\n0 3 * * * a"} {
+        if _, err := ParseCrontab(strings.NewReader(content)); err == nil {
+            t.Errorf("expected error for %q", content)
+        }
+    }
+}
+
+func TestLoader(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "crontab")
+    write := func(content string) {
+        if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+            t.Fatal(err)
+        }
+    }
+    specs := func(c *Cron) map[string]string {
+        m := map[string]string{}
+        for _, e := range c.Entries() {
+            m[e.Name] = e.Spec
+        }
+        return m
+    }
+
+    cron := New()
+    loader := NewLoader(cron, path)
+    loader.RegisterFunc("cleanup", func() {})
+    loader.RegisterFunc("report", func() {})
+    loader.RegisterFunc("poll", func() {})
+
+    write("0 2 * * * cleanup\n0 9 * * * report\n")
+    if err := loader.Load(); err != nil {
+        t.Fatal(err)
+    }
+    reportID := cron.EntryByName("report").ID
+
+    write("0 9 * * 1-5 report\n@every 10m poll\n")
+    if err := loader.Load(); err != nil {
+        t.Fatal(err)
+    }
+    if m := specs(cron); len(m) != 2 || m["report"] != "0 9 * * 1-5" || m["poll"] != "@every 10m" {
+        t.Errorf("unexpected entries after reload: %v", m)
+    }
+    if id := cron.EntryByName("report").ID; id != reportID {
+        t.Errorf("expected rescheduled entry to keep id %d, got %d", reportID, id)
+    }
+
+    for _, content := range []string{"0 9 * * * unknown\n", "0 99 * * * report\n"} {
+        write(content)
+        if err := loader.Load(); err == nil {
+            t.Errorf("expected error for %q", content)
+        }
+        if m := specs(cron); len(m) != 2 || m["report"] != "0 9 * * 1-5" {
+            t.Errorf("expected invalid file to leave entries unchanged, got %v", m)
+        }
+    }
+}
+
+func TestLoaderWatch(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "crontab")
+    if err := os.WriteFile(path, []byte("0 2 * * * cleanup\n"), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+    cron := New(WithClock(clock))
+    loader := NewLoader(cron, path)
+    loader.RegisterFunc("cleanup", func() {})
+    if err := loader.Load(); err != nil {
+        t.Fatal(err)
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    go loader.Watch(ctx, time.Second)
+
+    if err := os.WriteFile(path, []byte("30 2 * * * cleanup\n"), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    clock.BlockUntil(1)
+    clock.Advance(time.Second)
+    deadline := time.Now().Add(time.Second)
+    for cron.EntryByName("cleanup").Spec != "30 2 * * *" {
+        if time.Now().After(deadline) {
+            t.Fatalf("expected reload, got spec %q", cron.EntryByName("cleanup").Spec)
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+}
@@ -0,0 +1,132 @@
+package gcron
+
+import (
+    "fmt"
+    "slices"
+    "time"
+)
+
+// entryDependency 声明条目依赖关系的请求。
+type entryDependency struct {
+    id      EntryID
+    parents []EntryID
+    reply   chan error
+}
+
+// OnDemandSchedule 从不自行激活的计划，用于只由依赖触发的条目。
+type OnDemandSchedule struct{}
+
+// OnDemand 返回从不自行激活的计划。
+func OnDemand() OnDemandSchedule {
+    return OnDemandSchedule{}
+}
+
+// Next 总是返回零时间。
+func (OnDemandSchedule) Next(time.Time) time.Time {
+    return time.Time{}
+}
+
+// DependsOn 声明条目 id 依赖于 parents：所有 parents 都成功运行后，条目 id 运行一次。
+// 条目不存在、依赖自身或会形成循环依赖时返回错误，且不修改任何依赖关系。
+// 只由依赖触发的条目可使用 OnDemand 计划添加。
+func (c *Cron) DependsOn(id EntryID, parents ...EntryID) error {
+    c.runningMu.Lock()
+    defer c.runningMu.Unlock()
+    if c.running {
+        req := entryDependency{id: id, parents: parents, reply: make(chan error, 1)}
+        c.depend <- req
+        return <-req.reply
+    }
+    return c.dependEntry(id, parents)
+}
+
+// dependEntry 为当前 cron 指定的条目添加依赖。
+func (c *Cron) dependEntry(id EntryID, parents []EntryID) error {
+    e := c.entry(id)
+    if e == nil {
+        return fmt.Errorf("entry %d not found", id)
+    }
+    for _, parent := range parents {
+        p := c.entry(parent)
+        if p == nil {
+            return fmt.Errorf("entry %d not found", parent)
+        }
+        if c.reaches(id, parent) {
+            return fmt.Errorf("dependency of entry %d on entry %d would create a cycle", id, parent)
+        }
+    }
+    for _, parent := range parents {
+        if slices.Contains(e.DependsOn, parent) {
+            continue
+        }
+        p := c.entry(parent)
+        e.DependsOn = append(e.DependsOn, parent)
+        p.Dependents = append(p.Dependents, id)
+    }
+    return nil
+}
+
+// reaches 如果从条目 from 沿依赖方向可以到达条目 to（包括 from 等于 to），则返回 true。
+func (c *Cron) reaches(from, to EntryID) bool {
+    visited := map[EntryID]bool{}
+    stack := []EntryID{from}
+    for len(stack) > 0 {
+        id := stack[len(stack)-1]
+        stack = stack[:len(stack)-1]
+        if id == to {
+            return true
+        }
+        if visited[id] {
+            continue
+        }
+        visited[id] = true
+        if e := c.entry(id); e != nil {
+            stack = append(stack, e.Dependents...)
+        }
+    }
+    return false
+}
+
+// runDependents 在条目 id 调度于 scheduled 的运行成功后，运行所有依赖均已满足的下游条目。
+// 下游条目沿用上游的调度时间，使各实例的 SingletonAcrossInstances 对同一次运行使用相同的锁。
+func (c *Cron) runDependents(id EntryID, scheduled, now time.Time) {
+    e := c.entry(id)
+    if e == nil {
+        return
+    }
+    for _, child := range e.Dependents {
+        d := c.entry(child)
+        if d == nil {
+            continue
+        }
+        if d.satisfied == nil {
+            d.satisfied = make(map[EntryID]struct{}, len(d.DependsOn))
+        }
+        d.satisfied[id] = struct{}{}
+        if len(d.satisfied) < len(d.DependsOn) || d.Paused {
+            continue
+        }
+        clear(d.satisfied)
+        c.startJob(d, scheduled)
+        d.Prev = now
+        c.saveEntry(d)
+        if expired(d.Schedule, now, d.runs) {
+            c.removeEntry(d.ID)
+        }
+    }
+}
+
+// unlinkEntry 移除条目的所有依赖关系。
+func (c *Cron) unlinkEntry(e *Entry) {
+    for _, parent := range e.DependsOn {
+        if p := c.entry(parent); p != nil {
+            p.Dependents = slices.DeleteFunc(slices.Clone(p.Dependents), func(id EntryID) bool { return id == e.ID })
+        }
+    }
+    for _, child := range e.Dependents {
+        if d := c.entry(child); d != nil {
+            d.DependsOn = slices.DeleteFunc(slices.Clone(d.DependsOn), func(id EntryID) bool { return id == e.ID })
+            delete(d.satisfied, e.ID)
+        }
+    }
+}
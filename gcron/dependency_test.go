@@ -0,0 +1,204 @@
+package gcron
+
+import (
+    "context"
+    "errors"
+    "slices"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestDependsOn(t *testing.T) {
+    var fail atomic.Bool
+    ran := make(chan struct{}, 2)
+    parent := FuncErrorJob(func(context.Context) error {
+        if fail.Load() {
+            return errors.New("failed")
+        }
+        return nil
+    })
+    cron, fire := fireEvery(parent, nil)
+    defer cron.Stop(context.Background())
+    child := cron.Schedule(OnDemand(), FuncJob(func() { ran <- struct{}{} }))
+    if err := cron.DependsOn(child, 1); err != nil {
+        t.Fatal(err)
+    }
+
+    fire()
+    expectSignal(t, ran, "expected child to run after parent succeeded")
+
+    fail.Store(true)
+    fire()
+    expectNoSignal(t, ran, "expected child not to run after parent failed")
+
+    if e := cron.Entry(child); !slices.Equal(e.DependsOn, []EntryID{1}) {
+        t.Errorf("expected child to depend on 1, got %v", e.DependsOn)
+    }
+    if e := cron.Entry(1); !slices.Equal(e.Dependents, []EntryID{child}) {
+        t.Errorf("expected parent dependents [%d], got %v", child, e.Dependents)
+    }
+    if next := cron.Entry(child).Next; !next.IsZero() {
+        t.Errorf("expected on-demand entry to have zero Next, got %v", next)
+    }
+}
+
+func TestDependsOnWorkerPoolBlock(t *testing.T) {
+    started, release, ran := make(chan struct{}, 3), make(chan struct{}), make(chan struct{}, 2)
+    cron, fire := fireEvery(blockingJob(started, release), []Option{WithWorkerPool(1, 0, OverflowBlock)})
+    defer cron.Stop(context.Background())
+    child := cron.Schedule(OnDemand(), FuncJob(func() { ran <- struct{}{} }))
+    if err := cron.DependsOn(child, 1); err != nil {
+        t.Fatal(err)
+    }
+
+    fire()
+    expectSignal(t, started, "expected first run")
+    fire()
+    expectNoSignal(t, started, "expected second run blocked on full pool")
+
+    // 上游任务成功时，调度程序仍阻塞在提交上，任务不能反过来阻塞在通知调度程序上。
+    close(release)
+    expectSignal(t, ran, "expected child to run after parent succeeded")
+    done := make(chan struct{})
+    go func() {
+        cron.Entries()
+        close(done)
+    }()
+    expectSignal(t, done, "expected scheduler to keep serving requests")
+}
+
+func TestDependsOnSkippedParent(t *testing.T) {
+    started, release, ran := make(chan struct{}, 3), make(chan struct{}), make(chan struct{}, 3)
+    cron, fire := fireEvery(blockingJob(started, release), []Option{WithChain(SkipIfStillRunning())})
+    defer cron.Stop(context.Background())
+    child := cron.Schedule(OnDemand(), FuncJob(func() { ran <- struct{}{} }))
+    if err := cron.DependsOn(child, 1); err != nil {
+        t.Fatal(err)
+    }
+
+    fire()
+    expectSignal(t, started, "expected first run")
+    fire()
+    expectNoSignal(t, ran, "expected child not to run after parent was skipped")
+    close(release)
+    expectSignal(t, ran, "expected child to run after parent succeeded")
+    expectNoSignal(t, ran, "expected child to run once")
+}
+
+func TestDependsOnSingletonAcrossInstances(t *testing.T) {
+    clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+    locker := NewMemoryLocker()
+    parents, children := make(chan struct{}, 4), make(chan time.Time, 4)
+    var parentRuns atomic.Int32
+
+    // 两个实例共享同一个锁，只有获得上游锁的实例运行下游条目。
+    for i := 0; i < 2; i++ {
+        cron := New(WithClock(clock), WithLocation(time.UTC),
+            WithChain(SingletonAcrossInstances(locker)),
+            WithOnJobSkipped(func(run JobRun) {
+                if run.Name == "parent" {
+                    parents <- struct{}{}
+                } else {
+                    children <- run.Scheduled
+                }
+            }),
+        )
+        parent, _ := cron.AddFunc("* * * * *", func() {
+            parentRuns.Add(1)
+            parents <- struct{}{}
+        }, WithEntryName("parent"))
+        child := cron.Schedule(OnDemand(), FuncContextJob(func(ctx context.Context) {
+            info, _ := JobInfoFromContext(ctx)
+            children <- info.Scheduled
+        }), WithEntryName("child"))
+        if err := cron.DependsOn(child, parent); err != nil {
+            t.Fatal(err)
+        }
+        cron.Start()
+        defer cron.Stop(context.Background())
+    }
+
+    clock.BlockUntil(2)
+    clock.Advance(time.Minute + 10*time.Second)
+    for i := 0; i < 2; i++ {
+        expectSignal(t, parents, "expected both instances to attempt the parent")
+    }
+    select {
+    case scheduled := <-children:
+        if want := time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC); !scheduled.Equal(want) {
+            t.Errorf("expected child scheduled at parent's time %v, got %v", want, scheduled)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("expected child to run once")
+    }
+    select {
+    case <-children:
+        t.Error("expected only the instance that ran the parent to start the child")
+    case <-time.After(50 * time.Millisecond):
+    }
+    if n := parentRuns.Load(); n != 1 {
+        t.Errorf("expected parent run once across instances, got %d", n)
+    }
+}
+
+func TestDependsOnAllParents(t *testing.T) {
+    clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+    cron := New(WithClock(clock), WithLocation(time.UTC))
+    ran := make(chan struct{}, 2)
+    a := cron.Schedule(Every(time.Second), FuncJob(func() {}))
+    b := cron.Schedule(Every(2*time.Second), FuncJob(func() {}))
+    c := cron.Schedule(OnDemand(), FuncJob(func() { ran <- struct{}{} }))
+    if err := cron.DependsOn(c, a, b); err != nil {
+        t.Fatal(err)
+    }
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    clock.BlockUntil(1)
+    clock.Advance(time.Second)
+    expectNoSignal(t, ran, "expected child to wait for every parent")
+    clock.BlockUntil(1)
+    clock.Advance(time.Second)
+    expectSignal(t, ran, "expected child to run after every parent succeeded")
+}
+
+func TestDependsOnErrors(t *testing.T) {
+    cron := New()
+    a := cron.Schedule(OnDemand(), FuncJob(func() {}))
+    b := cron.Schedule(OnDemand(), FuncJob(func() {}))
+    c := cron.Schedule(OnDemand(), FuncJob(func() {}))
+    if err := cron.DependsOn(b, a); err != nil {
+        t.Fatal(err)
+    }
+    if err := cron.DependsOn(c, b); err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        name    string
+        id      EntryID
+        parents []EntryID
+    }{
+        {"self", a, []EntryID{a}},
+        {"cycle", a, []EntryID{c}},
+        {"unknown entry", 99, []EntryID{a}},
+        {"unknown parent", a, []EntryID{99}},
+    }
+    for _, test := range tests {
+        if err := cron.DependsOn(test.id, test.parents...); err == nil {
+            t.Errorf("%s: expected error", test.name)
+        }
+    }
+    if e := cron.Entry(a); len(e.DependsOn) != 0 {
+        t.Errorf("expected failed declarations to leave no dependencies, got %v", e.DependsOn)
+    }
+
+    cron.Remove(b)
+    if e := cron.Entry(a); len(e.Dependents) != 0 {
+        t.Errorf("expected removed entry to be unlinked from parent, got %v", e.Dependents)
+    }
+    if e := cron.Entry(c); len(e.DependsOn) != 0 {
+        t.Errorf("expected removed entry to be unlinked from child, got %v", e.DependsOn)
+    }
+}
@@ -0,0 +1,482 @@
+package gcron
+
+import (
+    "fmt"
+    "strings"
+    "time"
+    "unicode/utf8"
+)
+
+// Language 描述计划时使用的语言。
+type Language int
+
+const (
+    English Language = iota // 英文，例如 "At 09:00 on Monday through Friday"。
+    Chinese                 // 中文，例如 "周一至周五，09:00"。
+)
+
+// maxListedTimes 时、分、秒均为固定值时，最多逐个列出的运行时间数。
+const maxListedTimes = 6
+
+// Describe 返回计划的英文描述，例如 "At 09:00 on Monday through Friday"。
+func Describe(s *SpecSchedule) string {
+    return DescribeIn(s, English)
+}
+
+// DescribeIn 返回计划在指定语言下的描述。
+func DescribeIn(s *SpecSchedule, lang Language) string {
+    d := describer{s: s, zh: lang == Chinese}
+    if d.zh {
+        return d.chinese()
+    }
+    return d.english()
+}
+
+// span 字段中一段连续的取值。
+type span struct {
+    from, to uint
+}
+
+// fieldValues 字段位集展开后的取值。
+type fieldValues struct {
+    all    bool   // 字段为 * 或覆盖全部取值。
+    values []uint // 升序排列的取值。
+}
+
+// valuesOf 返回位集在边界内的取值。
+func valuesOf(bits uint64, r bounds) fieldValues {
+    var values []uint
+    for v := r.min; v <= r.max; v++ {
+        if 1<<v&bits > 0 {
+            values = append(values, v)
+        }
+    }
+    return fieldValues{
+        all:    bits&starBit > 0 || uint(len(values)) == r.max-r.min+1,
+        values: values,
+    }
+}
+
+// step 如果取值是从 from 开始直到边界末尾的等差数列，则返回起点和步长。
+func (f fieldValues) step(r bounds) (from, step uint, ok bool) {
+    if len(f.values) < 3 {
+        return 0, 0, false
+    }
+    step = f.values[1] - f.values[0]
+    for i := 2; i < len(f.values); i++ {
+        if f.values[i]-f.values[i-1] != step {
+            return 0, 0, false
+        }
+    }
+    last := f.values[len(f.values)-1]
+    return f.values[0], step, step > 1 && last+step > r.max
+}
+
+// single 如果只有一个取值，则返回该值。
+func (f fieldValues) single() (uint, bool) {
+    if len(f.values) == 1 {
+        return f.values[0], true
+    }
+    return 0, false
+}
+
+// spans 将取值合并为连续的区间，三个及以上连续值合并为一个区间。
+func (f fieldValues) spans() []span {
+    var spans []span
+    for i := 0; i < len(f.values); {
+        j := i
+        for j+1 < len(f.values) && f.values[j+1] == f.values[j]+1 {
+            j++
+        }
+        if j-i >= 2 {
+            spans = append(spans, span{f.values[i], f.values[j]})
+            i = j + 1
+            continue
+        }
+        spans = append(spans, span{f.values[i], f.values[i]})
+        i++
+    }
+    return spans
+}
+
+// describer 生成计划的描述。
+type describer struct {
+    s  *SpecSchedule
+    zh bool
+}
+
+// join 按语言连接多个项目，例如 "a, b and c" 或 "a、b 和 c"。
+func (d describer) join(items []string) string {
+    switch len(items) {
+    case 0:
+        return ""
+    case 1:
+        return items[0]
+    }
+    if d.zh {
+        return zhConcat(strings.Join(items[:len(items)-1], "、"), "和", items[len(items)-1])
+    }
+    return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// or 按语言以“或”连接多个项目。
+func (d describer) or(items []string) string {
+    if d.zh {
+        return zhConcat(strings.Join(items, "或"))
+    }
+    return strings.Join(items, " or ")
+}
+
+// zhConcat 连接中文片段，在数字、字母与汉字之间补充空格。
+func zhConcat(parts ...string) string {
+    var b strings.Builder
+    for _, part := range parts {
+        if part == "" {
+            continue
+        }
+        if b.Len() > 0 {
+            prev, _ := utf8.DecodeLastRuneInString(b.String())
+            next, _ := utf8.DecodeRuneInString(part)
+            if prev != ' ' && next != ' ' && isAlnum(prev) != isAlnum(next) {
+                b.WriteByte(' ')
+            }
+        }
+        b.WriteString(part)
+    }
+    return b.String()
+}
+
+// isAlnum 如果 r 是 ASCII 字母或数字，则返回 true。
+func isAlnum(r rune) bool {
+    return r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// list 将取值描述为列表，区间以 through、至 连接。
+func (d describer) list(f fieldValues, format func(uint) string) string {
+    var items []string
+    for _, sp := range f.spans() {
+        switch {
+        case sp.from == sp.to:
+            items = append(items, format(sp.from))
+        case d.zh:
+            items = append(items, zhConcat(format(sp.from), "至", format(sp.to)))
+        default:
+            items = append(items, format(sp.from)+" through "+format(sp.to))
+        }
+    }
+    return d.join(items)
+}
+
+// plural 如果取值多于一个则返回英文复数形式。
+func plural(f fieldValues, word string) string {
+    if len(f.values) > 1 {
+        return word + "s"
+    }
+    return word
+}
+
+// clock 返回时、分、秒的时间文本，秒为 0 时省略。
+func clock(h, m, s uint) string {
+    if s == 0 {
+        return fmt.Sprintf("%02d:%02d", h, m)
+    }
+    return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
+
+// times 时、分、秒均为固定值（小时可以有少量取值）时，返回逐个列出的运行时间。
+func (d describer) times() []string {
+    var (
+        sec, secOk = valuesOf(d.s.Second, seconds).single()
+        min, minOk = valuesOf(d.s.Minute, minutes).single()
+        hour       = valuesOf(d.s.Hour, hours)
+    )
+    if !secOk || !minOk || hour.all || len(hour.values) > maxListedTimes {
+        return nil
+    }
+    var times []string
+    for _, h := range hour.values {
+        times = append(times, clock(h, min, sec))
+    }
+    return times
+}
+
+// field 描述时、分、秒字段中的一个。
+func (d describer) field(bits uint64, r bounds, unit, unitZh string) string {
+    f := valuesOf(bits, r)
+    if f.all {
+        if d.zh {
+            return "每" + unitZh
+        }
+        return "every " + unit
+    }
+    if from, step, ok := f.step(r); ok {
+        if d.zh {
+            if from == r.min {
+                return fmt.Sprintf("每 %d %s", step, unitZh)
+            }
+            return fmt.Sprintf("从第 %d %s起每 %d %s", from, unitZh, step, unitZh)
+        }
+        if from == r.min {
+            return fmt.Sprintf("every %d %ss", step, unit)
+        }
+        return fmt.Sprintf("every %d %ss starting at %s %d", step, unit, unit, from)
+    }
+    number := func(v uint) string { return fmt.Sprint(v) }
+    if d.zh {
+        return "第 " + d.list(f, number) + " " + unitZh
+    }
+    return "at " + plural(f, unit) + " " + d.list(f, number)
+}
+
+// timeClauses 描述一天中的运行时间。
+func (d describer) timeClauses() []string {
+    if times := d.times(); times != nil {
+        return []string{d.join(times)}
+    }
+    var (
+        clauses []string
+        sec     = valuesOf(d.s.Second, seconds)
+        min     = valuesOf(d.s.Minute, minutes)
+        hour    = valuesOf(d.s.Hour, hours)
+    )
+    if v, ok := sec.single(); !ok || v != 0 {
+        clauses = append(clauses, d.field(d.s.Second, seconds, "second", "秒"))
+    }
+    if !min.all || !sec.all {
+        clause := d.field(d.s.Minute, minutes, "minute", "分钟")
+        if _, ok := min.single(); ok && hour.all {
+            if d.zh {
+                clause = "每小时" + clause
+            } else {
+                clause += " past every hour"
+            }
+        }
+        clauses = append(clauses, clause)
+    }
+    if !hour.all {
+        if _, _, ok := hour.step(hours); ok || d.zh {
+            clauses = append(clauses, d.hourClause(hour))
+        } else {
+            clauses = append(clauses, "past "+plural(hour, "hour")+" "+d.list(hour, func(v uint) string { return fmt.Sprint(v) }))
+        }
+    }
+    return clauses
+}
+
+// hourClause 描述小时字段。
+func (d describer) hourClause(hour fieldValues) string {
+    if _, _, ok := hour.step(hours); ok {
+        return d.field(d.s.Hour, hours, "hour", "小时")
+    }
+    return d.list(hour, func(v uint) string { return fmt.Sprintf("%d 点", v) })
+}
+
+// weekday 返回星期的名称。
+func (d describer) weekday(v uint) string {
+    if d.zh {
+        return "周" + string([]rune("日一二三四五六")[v])
+    }
+    return time.Weekday(v).String()
+}
+
+// month 返回月份的名称。
+func (d describer) month(v uint) string {
+    if d.zh {
+        return fmt.Sprintf("%d 月", v)
+    }
+    return time.Month(v).String()
+}
+
+// ordinal 返回序数词。
+func (d describer) ordinal(n uint) string {
+    if d.zh {
+        return fmt.Sprintf("第 %d 个", n)
+    }
+    return [...]string{"", "first", "second", "third", "fourth", "fifth"}[n]
+}
+
+// domItems 描述日字段，不含“每月”等前后缀。
+func (d describer) domItems() []string {
+    var items []string
+    if f := valuesOf(d.s.Dom, dom); len(f.values) > 0 {
+        if d.zh {
+            items = append(items, d.list(f, func(v uint) string { return fmt.Sprintf("%d 日", v) }))
+        } else {
+            items = append(items, plural(f, "day")+" "+d.list(f, func(v uint) string { return fmt.Sprint(v) }))
+        }
+    }
+    e := d.s.ext
+    if e == nil {
+        return items
+    }
+    for n := uint(0); n < dom.max; n++ {
+        if 1<<n&e.lastDom == 0 {
+            continue
+        }
+        switch {
+        case n == 0 && d.zh:
+            items = append(items, "最后一天")
+        case n == 0:
+            items = append(items, "the last day")
+        case d.zh:
+            items = append(items, fmt.Sprintf("最后一天前 %d 天", n))
+        case n == 1:
+            items = append(items, "1 day before the last day")
+        default:
+            items = append(items, fmt.Sprintf("%d days before the last day", n))
+        }
+    }
+    if e.lastWeekday {
+        if d.zh {
+            items = append(items, "最后一个工作日")
+        } else {
+            items = append(items, "the last weekday")
+        }
+    }
+    for n := dom.min; n <= dom.max; n++ {
+        if 1<<n&e.nearestWeekday == 0 {
+            continue
+        }
+        if d.zh {
+            items = append(items, fmt.Sprintf("离 %d 日最近的工作日", n))
+        } else {
+            items = append(items, fmt.Sprintf("the weekday nearest day %d", n))
+        }
+    }
+    return items
+}
+
+// dowItems 描述周字段，分别返回普通星期和按月计算的星期（L、#）。
+func (d describer) dowItems() (plain string, monthly []string) {
+    if f := valuesOf(d.s.Dow, dow); len(f.values) > 0 {
+        plain = d.list(f, d.weekday)
+    }
+    e := d.s.ext
+    if e == nil {
+        return plain, nil
+    }
+    for w := dow.min; w <= dow.max; w++ {
+        if 1<<w&e.lastDow > 0 {
+            if d.zh {
+                monthly = append(monthly, "最后一个"+d.weekday(w))
+            } else {
+                monthly = append(monthly, "the last "+d.weekday(w))
+            }
+        }
+        for n := uint(1); n <= 5; n++ {
+            if 1<<n&e.nthDow[w] > 0 {
+                if d.zh {
+                    monthly = append(monthly, d.ordinal(n)+d.weekday(w))
+                } else {
+                    monthly = append(monthly, "the "+d.ordinal(n)+" "+d.weekday(w))
+                }
+            }
+        }
+    }
+    return plain, monthly
+}
+
+// dayClause 描述运行的日期，日、周字段都不限制时返回空字符串。
+func (d describer) dayClause() string {
+    // 日、周字段中有一个为 * 时只需满足另一个，都不为 * 时满足任意一个即可。
+    var (
+        domStar = d.s.Dom&starBit > 0
+        dowStar = d.s.Dow&starBit > 0
+        parts   []string
+    )
+    if !domStar {
+        if items := d.domItems(); len(items) > 0 {
+            if d.zh {
+                parts = append(parts, zhConcat("每月", d.join(items)))
+            } else {
+                parts = append(parts, "on "+d.join(items)+" of the month")
+            }
+        }
+    }
+    if !dowStar {
+        plain, monthly := d.dowItems()
+        if plain != "" {
+            if d.zh {
+                parts = append(parts, plain)
+            } else {
+                parts = append(parts, "on "+plain)
+            }
+        }
+        if len(monthly) > 0 {
+            if d.zh {
+                parts = append(parts, zhConcat("每月", d.join(monthly)))
+            } else {
+                parts = append(parts, "on "+d.join(monthly)+" of the month")
+            }
+        }
+    }
+    return d.or(parts)
+}
+
+// yearClause 描述年字段，不限制时返回空字符串。
+func (d describer) yearClause() string {
+    if d.s.ext == nil || d.s.ext.years == nil {
+        return ""
+    }
+    var f fieldValues
+    for _, y := range d.s.ext.years {
+        f.values = append(f.values, uint(y))
+    }
+    if d.zh {
+        return d.list(f, func(v uint) string { return fmt.Sprintf("%d 年", v) })
+    }
+    return "in " + d.list(f, func(v uint) string { return fmt.Sprint(v) })
+}
+
+// location 返回计划时区的描述，本地时区返回空字符串。
+func (d describer) location() string {
+    if d.s.Location == nil || d.s.Location == time.Local {
+        return ""
+    }
+    if d.zh {
+        return "（" + d.s.Location.String() + "）"
+    }
+    return " (" + d.s.Location.String() + ")"
+}
+
+// english 返回英文描述。
+func (d describer) english() string {
+    var (
+        clauses = d.timeClauses()
+        text    = strings.Join(clauses, ", ")
+    )
+    if d.times() != nil {
+        text = "at " + text
+    }
+    if day := d.dayClause(); day != "" {
+        text += " " + day
+    }
+    if f := valuesOf(d.s.Month, months); !f.all {
+        text += " in " + d.list(f, d.month)
+    }
+    if year := d.yearClause(); year != "" {
+        text += " " + year
+    }
+    return strings.ToUpper(text[:1]) + text[1:] + d.location()
+}
+
+// chinese 返回中文描述。
+func (d describer) chinese() string {
+    var parts []string
+    if year := d.yearClause(); year != "" {
+        parts = append(parts, year)
+    }
+    if f := valuesOf(d.s.Month, months); !f.all {
+        parts = append(parts, d.list(f, d.month))
+    }
+    day := d.dayClause()
+    if day != "" {
+        parts = append(parts, day)
+    }
+    clauses := d.timeClauses()
+    if day == "" && d.times() != nil {
+        clauses[0] = "每天 " + clauses[0]
+    }
+    parts = append(parts, clauses...)
+    return strings.Join(parts, "，") + d.location()
+}
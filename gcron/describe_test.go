@@ -0,0 +1,42 @@
+package gcron
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+    parser := NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional | Last | NearestWeekday | NthWeekday | Descriptor)
+    tests := []struct {
+        spec    string
+        english string
+        chinese string
+    }{
+        {"0 0 9 * * MON-FRI", "At 09:00 on Monday through Friday", "周一至周五，09:00"},
+        {"30 0 9,17 * * *", "At 09:00:30 and 17:00:30", "每天 09:00:30 和 17:00:30"},
+        {"* * * * * *", "Every second", "每秒"},
+        {"0 * * * * *", "Every minute", "每分钟"},
+        {"@hourly", "At minute 0 past every hour", "每小时第 0 分钟"},
+        {"0 5/10 * * * *", "Every 10 minutes starting at minute 5", "从第 5 分钟起每 10 分钟"},
+        {"0 */15 9-17 * * 1-5", "Every 15 minutes, past hours 9 through 17 on Monday through Friday", "周一至周五，每 15 分钟，9 点至 17 点"},
+        {"0 0 0 1,15 * *", "At 00:00 on days 1 and 15 of the month", "每月 1 日和 15 日，00:00"},
+        {"0 0 0 1 * MON", "At 00:00 on day 1 of the month or on Monday", "每月 1 日或周一，00:00"},
+        {"0 0 0 L * ?", "At 00:00 on the last day of the month", "每月最后一天，00:00"},
+        {"0 0 0 15W * ?", "At 00:00 on the weekday nearest day 15 of the month", "每月离 15 日最近的工作日，00:00"},
+        {"0 0 10 ? * 2#2", "At 10:00 on the second Tuesday of the month", "每月第 2 个周二，10:00"},
+        {"0 0 10 ? * FRIL", "At 10:00 on the last Friday of the month", "每月最后一个周五，10:00"},
+        {"0 0 0 1 1 ? 2030", "At 00:00 on day 1 of the month in January in 2030", "2030 年，1 月，每月 1 日，00:00"},
+        {"TZ=Asia/Shanghai 0 0 8 * jan-mar sun", "At 08:00 on Sunday in January through March (Asia/Shanghai)", "1 月至 3 月，周日，08:00（Asia/Shanghai）"},
+    }
+
+    for _, test := range tests {
+        sched, err := parser.Parse(test.spec)
+        if err != nil {
+            t.Error(err)
+            continue
+        }
+        if actual := Describe(sched.(*SpecSchedule)); actual != test.english {
+            t.Errorf("%s: (expected) %q != %q (actual)", test.spec, test.english, actual)
+        }
+        if actual := DescribeIn(sched.(*SpecSchedule), Chinese); actual != test.chinese {
+            t.Errorf("%s: (expected) %q != %q (actual)", test.spec, test.chinese, actual)
+        }
+    }
+}
@@ -0,0 +1,129 @@
+package gcron
+
+import (
+    "sort"
+    "time"
+)
+
+// DSTPolicy 描述夏令时切换当天如何处理不存在或重复出现的本地时间。
+//
+// 仅对小时字段不是每小时都匹配的计划生效；如 "0 * * * *" 这类每小时运行的计划始终按绝对时间运行，
+// 不受策略影响。
+type DSTPolicy int
+
+const (
+    DSTDefault DSTPolicy = iota // 保持原有行为：跳过不存在的时间，重复出现的时间运行两次。
+    DSTSkip                     // 跳过不存在的时间，重复出现的时间只在第一次出现时运行。
+    DSTRunOnce                  // 不存在的时间在切换后的第一个有效时刻运行一次，重复出现的时间只在第一次出现时运行。
+    DSTRunBoth                  // 不存在的时间在切换后的第一个有效时刻运行一次，重复出现的时间两次都运行。
+)
+
+func (p DSTPolicy) String() string {
+    switch p {
+    case DSTDefault:
+        return "default"
+    case DSTSkip:
+        return "skip"
+    case DSTRunOnce:
+        return "run-once"
+    case DSTRunBoth:
+        return "run-both"
+    default:
+        return ""
+    }
+}
+
+// everyHour 小时字段匹配每一小时时的位集。
+var everyHour = getBits(hours.min, hours.max, 1)
+
+// nextDST 按本地时间（墙上时间）查找下一个匹配的时间，再按 DST 策略将其换算为绝对时间。
+// 匹配在没有夏令时的 UTC 上进行，因此每个本地时间都只被考虑一次。
+func (s *SpecSchedule) nextDST(t time.Time, loc *time.Location) time.Time {
+    wall := wallClock(t)
+    // t 处于夏令时结束前重复出现的时段中时，从重复时段的开头查找，使第二次出现的时间不被遗漏。
+    if _, end := t.ZoneBounds(); !end.IsZero() {
+        if w := wallClock(end); w.Before(wall) {
+            wall = w.Add(-time.Nanosecond)
+        }
+    }
+    for {
+        wall = s.next(wall, time.UTC)
+        if wall.IsZero() {
+            return wall
+        }
+        instants := resolveWall(wall, loc)
+        switch {
+        case len(instants) == 0:
+            // 本地时间落在夏令时切换的间隙中。
+            if s.DST == DSTSkip {
+                continue
+            }
+            if at := gapEnd(wall, loc); at.After(t) {
+                return at
+            }
+        case len(instants) > 1 && s.DST == DSTRunBoth:
+            for _, at := range instants {
+                if at.After(t) {
+                    return at
+                }
+            }
+        default:
+            // 重复出现的时间只认第一次，第一次已过去则跳过。
+            if instants[0].After(t) {
+                return instants[0]
+            }
+        }
+    }
+}
+
+// wallClock 返回与 t 墙上时间相同的 UTC 时间。
+func wallClock(t time.Time) time.Time {
+    return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// resolveWall 返回 loc 中墙上时间为 wall 的所有时刻，升序排列。
+// 不存在时返回空，夏令时结束时重复出现的时间返回两个时刻。
+func resolveWall(wall time.Time, loc *time.Location) []time.Time {
+    guess := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+    start, end := guess.ZoneBounds()
+    probes := []time.Time{guess}
+    if !start.IsZero() {
+        probes = append(probes, start.Add(-time.Nanosecond))
+    }
+    if !end.IsZero() {
+        probes = append(probes, end)
+    }
+
+    var instants []time.Time
+    for _, probe := range probes {
+        _, offset := probe.Zone()
+        at := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+        if !wallClock(at).Equal(wall) {
+            continue
+        }
+        dup := false
+        for _, i := range instants {
+            dup = dup || i.Equal(at)
+        }
+        if !dup {
+            instants = append(instants, at)
+        }
+    }
+    sort.Slice(instants, func(i, j int) bool { return instants[i].Before(instants[j]) })
+    return instants
+}
+
+// gapEnd 返回跳过 wall 的夏令时切换时刻，即间隙之后的第一个有效时刻。
+func gapEnd(wall time.Time, loc *time.Location) time.Time {
+    guess := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+    start, end := guess.ZoneBounds()
+    for _, at := range []time.Time{start, end} {
+        if at.IsZero() {
+            continue
+        }
+        if !wallClock(at).Before(wall) && wallClock(at.Add(-time.Nanosecond)).Before(wall) {
+            return at
+        }
+    }
+    return guess
+}
@@ -0,0 +1,76 @@
+package gcron
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/camry/g/v2/gerrors/gcode"
+    "github.com/camry/g/v2/gerrors/gerror"
+)
+
+// fieldNames 各字段的名称，按解析后的字段位置排列。
+var fieldNames = []string{"second", "minute", "hour", "day of month", "month", "day of week", "year"}
+
+// fieldBounds 各字段的取值范围，按解析后的字段位置排列。
+var fieldBounds = []bounds{seconds, minutes, hours, dom, months, dow, years}
+
+// ParseErrorDetail 调度规范解析错误的详细信息，作为错误码 gcode.CodeInvalidParameter 的 Detail 返回。
+type ParseErrorDetail struct {
+    Spec   string // 出错的调度规范。
+    Field  int    // 出错字段的位置（0 秒、1 分、2 时、3 日、4 月、5 周、6 年），与具体字段无关时为 -1。
+    Name   string // 出错字段的名称，例如 "minute"，与具体字段无关时为空。
+    Offset int    // 出错表达式在规范中的字节偏移，无法确定时为 -1。
+    Min    uint   // 字段取值下限。
+    Max    uint   // 字段取值上限。
+}
+
+// ParseErrorDetailOf 返回 err 携带的调度规范解析错误详情。
+func ParseErrorDetailOf(err error) (ParseErrorDetail, bool) {
+    detail, ok := gerror.Code(err).Detail().(ParseErrorDetail)
+    return detail, ok
+}
+
+// newSpecError 返回与具体字段无关的解析错误，offset 无法确定时为 -1。
+func newSpecError(spec string, offset int, format string, args ...any) error {
+    detail := ParseErrorDetail{Spec: spec, Field: -1, Offset: offset}
+    return gerror.NewCode(gcode.WithCode(gcode.CodeInvalidParameter, detail), fmt.Sprintf(format, args...))
+}
+
+// newFieldError 返回字段 place 的解析错误，offset 为字段在规范中的字节偏移，field 为字段原文。
+// 错误来自字段中的某个表达式时，偏移定位到该表达式。
+func newFieldError(spec string, place, offset int, field string, err error) error {
+    var ee *exprError
+    if errors.As(err, &ee) {
+        if i := strings.Index(field, ee.expr); i >= 0 {
+            offset += i
+        }
+    }
+    detail := ParseErrorDetail{
+        Spec:   spec,
+        Field:  place,
+        Name:   fieldNames[place],
+        Offset: offset,
+        Min:    fieldBounds[place].min,
+        Max:    fieldBounds[place].max,
+    }
+    return gerror.NewCodef(gcode.WithCode(gcode.CodeInvalidParameter, detail), "%s field at offset %d: %v", detail.Name, offset, err)
+}
+
+// exprError 字段中某个表达式的解析错误。
+type exprError struct {
+    expr string
+    err  error
+}
+
+func (e *exprError) Error() string { return e.err.Error() }
+
+func (e *exprError) Unwrap() error { return e.err }
+
+// wrapExpr 将 err 标记为来自表达式 expr，err 为 nil 时返回 nil。
+func wrapExpr(expr string, err error) error {
+    if err == nil {
+        return nil
+    }
+    return &exprError{expr: expr, err: err}
+}
@@ -0,0 +1,106 @@
+package gcron
+
+import (
+    "container/heap"
+    "sort"
+    "time"
+)
+
+// entryHeap 按 Next 排列条目的最小堆（Next 为零时间的条目排在最后），并按 ID 索引条目。
+// 添加、删除和调整单个条目的位置都是 O(log n)，取下一个要运行的条目是 O(1)。
+type entryHeap struct {
+    items []*Entry
+    byID  map[EntryID]*Entry
+}
+
+var _ heap.Interface = (*entryHeap)(nil)
+
+func (h *entryHeap) Len() int           { return len(h.items) }
+func (h *entryHeap) Less(i, j int) bool { return byTime(h.items).Less(i, j) }
+func (h *entryHeap) Swap(i, j int) {
+    h.items[i], h.items[j] = h.items[j], h.items[i]
+    h.items[i].index = i
+    h.items[j].index = j
+}
+
+// Push 供 container/heap 使用，请使用 push 添加条目。
+func (h *entryHeap) Push(x any) {
+    e := x.(*Entry)
+    e.index = len(h.items)
+    h.items = append(h.items, e)
+}
+
+// Pop 供 container/heap 使用，请使用 remove 删除条目。
+func (h *entryHeap) Pop() any {
+    n := len(h.items) - 1
+    e := h.items[n]
+    h.items[n] = nil
+    h.items = h.items[:n]
+    e.index = -1
+    return e
+}
+
+// push 添加条目。
+func (h *entryHeap) push(e *Entry) {
+    if h.byID == nil {
+        h.byID = make(map[EntryID]*Entry)
+    }
+    h.byID[e.ID] = e
+    heap.Push(h, e)
+}
+
+// remove 删除指定 ID 的条目，条目不存在时什么也不做。
+func (h *entryHeap) remove(id EntryID) {
+    e, ok := h.byID[id]
+    if !ok {
+        return
+    }
+    delete(h.byID, id)
+    heap.Remove(h, e.index)
+}
+
+// get 返回指定 ID 的条目，如果找不到，则返回 nil。
+func (h *entryHeap) get(id EntryID) *Entry {
+    return h.byID[id]
+}
+
+// fix 在条目的 Next 改变后调整其在堆中的位置。
+func (h *entryHeap) fix(e *Entry) {
+    heap.Fix(h, e.index)
+}
+
+// init 在批量修改条目的 Next 后重建堆。
+func (h *entryHeap) init() {
+    heap.Init(h)
+}
+
+// peek 返回 Next 最早的条目，没有条目时返回 nil。
+func (h *entryHeap) peek() *Entry {
+    if len(h.items) == 0 {
+        return nil
+    }
+    return h.items[0]
+}
+
+// popDue 取出所有 Next 不晚于 now 的条目，调用方更新 Next 后应通过 pushBack 放回。
+func (h *entryHeap) popDue(now time.Time) []*Entry {
+    var due []*Entry
+    for e := h.peek(); e != nil && !e.Next.IsZero() && !e.Next.After(now); e = h.peek() {
+        due = append(due, heap.Pop(h).(*Entry))
+    }
+    return due
+}
+
+// pushBack 放回由 popDue 取出的条目。
+func (h *entryHeap) pushBack(e *Entry) {
+    heap.Push(h, e)
+}
+
+// sorted 返回按 Next 排序的条目副本，Next 相同的条目按添加顺序排列。
+func (h *entryHeap) sorted() []*Entry {
+    entries := make([]*Entry, len(h.items))
+    copy(entries, h.items)
+    sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+    sort.Stable(byTime(entries))
+    return entries
+}
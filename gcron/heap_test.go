@@ -0,0 +1,126 @@
+package gcron
+
+import (
+    "fmt"
+    "math/rand/v2"
+    "sort"
+    "testing"
+    "time"
+)
+
+func TestEntryHeap(t *testing.T) {
+    var (
+        h    entryHeap
+        base = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    )
+    for i, offset := range []int{5, 3, 0, 1, 4} {
+        e := &Entry{ID: EntryID(i + 1)}
+        if offset > 0 {
+            e.Next = base.Add(time.Duration(offset) * time.Minute)
+        }
+        h.push(e)
+    }
+
+    ids := func() []EntryID {
+        var ids []EntryID
+        for _, e := range h.sorted() {
+            ids = append(ids, e.ID)
+        }
+        return ids
+    }
+    if actual := ids(); fmt.Sprint(actual) != "[4 2 5 1 3]" {
+        t.Errorf("unexpected order %v", actual)
+    }
+    if h.peek().ID != 4 {
+        t.Errorf("expected entry 4 first, got %d", h.peek().ID)
+    }
+
+    h.remove(4)
+    h.remove(4)
+    e := h.get(1)
+    e.Next = base
+    h.fix(e)
+    if actual := ids(); fmt.Sprint(actual) != "[1 2 5 3]" {
+        t.Errorf("unexpected order %v", actual)
+    }
+
+    due := h.popDue(base.Add(3 * time.Minute))
+    if len(due) != 2 || due[0].ID != 1 || due[1].ID != 2 || h.Len() != 2 {
+        t.Fatalf("unexpected due entries %v", due)
+    }
+    for _, e := range due {
+        e.Next = e.Next.Add(time.Hour)
+        h.pushBack(e)
+    }
+    if actual := ids(); fmt.Sprint(actual) != "[5 1 2 3]" {
+        t.Errorf("unexpected order %v", actual)
+    }
+    if h.get(3) == nil || h.get(4) != nil {
+        t.Error("unexpected index")
+    }
+}
+
+// benchmarkEntries 返回 n 个下一次运行时间随机分布在一天内的条目。
+func benchmarkEntries(n int) []*Entry {
+    base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    entries := make([]*Entry, n)
+    for i := range entries {
+        entries[i] = &Entry{ID: EntryID(i + 1), Next: base.Add(time.Duration(rand.Int64N(int64(24 * time.Hour))))}
+    }
+    return entries
+}
+
+// BenchmarkEntryHeap 模拟一次唤醒：运行最早的条目，并删除、重新添加一个条目。
+func BenchmarkEntryHeap(b *testing.B) {
+    for _, n := range []int{10000, 100000} {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            var h entryHeap
+            for _, e := range benchmarkEntries(n) {
+                h.push(e)
+            }
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                for _, e := range h.popDue(h.peek().Next) {
+                    e.Next = e.Next.Add(24 * time.Hour)
+                    h.pushBack(e)
+                }
+                e := h.get(EntryID(i%n + 1))
+                h.remove(e.ID)
+                h.push(e)
+            }
+        })
+    }
+}
+
+// BenchmarkSortedEntries 同 BenchmarkEntryHeap，但使用每次唤醒都排序、线性删除的切片。
+func BenchmarkSortedEntries(b *testing.B) {
+    for _, n := range []int{10000, 100000} {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            entries := benchmarkEntries(n)
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                sort.Sort(byTime(entries))
+                now := entries[0].Next
+                for _, e := range entries {
+                    if e.Next.After(now) {
+                        break
+                    }
+                    e.Next = e.Next.Add(24 * time.Hour)
+                }
+                id := EntryID(i%n + 1)
+                var (
+                    removed *Entry
+                    rest    []*Entry
+                )
+                for _, e := range entries {
+                    if e.ID == id {
+                        removed = e
+                    } else {
+                        rest = append(rest, e)
+                    }
+                }
+                entries = append(rest, removed)
+            }
+        })
+    }
+}
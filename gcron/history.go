@@ -0,0 +1,165 @@
+package gcron
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// defaultHistoryLimit 每个条目默认保留的运行记录数。
+const defaultHistoryLimit = 10
+
+// JobOutcome 任务运行结果。
+type JobOutcome int
+
+const (
+    JobSucceeded JobOutcome = iota // 任务正常结束。
+    JobFailed                      // 任务返回错误。
+    JobPanicked                    // 任务发生 panic。
+    JobSkipped                     // 任务被包装器跳过，例如 SkipIfStillRunning。
+)
+
+func (o JobOutcome) String() string {
+    switch o {
+    case JobSucceeded:
+        return "succeeded"
+    case JobFailed:
+        return "failed"
+    case JobPanicked:
+        return "panicked"
+    case JobSkipped:
+        return "skipped"
+    default:
+        return ""
+    }
+}
+
+// JobRun 一次任务运行的记录。
+type JobRun struct {
+    JobInfo
+
+    Start    time.Time     // 任务开始运行的时间，跳过时为跳过的时间。
+    End      time.Time     // 任务结束的时间，运行中或跳过时为零时间。
+    Duration time.Duration // 任务运行耗时。
+    Outcome  JobOutcome    // 任务运行结果。
+    Err      error         // 任务失败或 panic 的原因。
+    Panic    any           // 任务 panic 的值。
+}
+
+// jobHistory 条目最近若干次运行的有界记录。
+type jobHistory struct {
+    mu    sync.Mutex
+    limit int
+    runs  []JobRun
+}
+
+// add 追加一条运行记录，超出上限时丢弃最早的记录。
+func (h *jobHistory) add(run JobRun) {
+    if h == nil || h.limit <= 0 {
+        return
+    }
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    if len(h.runs) == h.limit {
+        copy(h.runs, h.runs[1:])
+        h.runs = h.runs[:len(h.runs)-1]
+    }
+    h.runs = append(h.runs, run)
+}
+
+// list 返回运行记录的副本，按记录时间从早到晚排列。
+func (h *jobHistory) list() []JobRun {
+    if h == nil {
+        return nil
+    }
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    if len(h.runs) == 0 {
+        return nil
+    }
+    runs := make([]JobRun, len(h.runs))
+    copy(runs, h.runs)
+    return runs
+}
+
+// jobTracker 记录一个条目的运行并触发 Cron 的生命周期钩子。
+type jobTracker struct {
+    c       *Cron
+    history *jobHistory
+    active  atomic.Int32 // 正在运行的任务数
+}
+
+// jobTrackerKey 任务上下文中 jobTracker 的键。
+type jobTrackerKey struct{}
+
+// trackJob 包装任务使其运行被记录到条目历史中，并触发开始、结束钩子。
+// 它位于 Chain 的最内层，因此只有真正运行的任务才会被记录；panic 会在记录后继续向外抛出。
+func trackJob(j Job) Job {
+    ej := toErrorJob(j)
+    return FuncErrorJob(func(ctx context.Context) error {
+        t, ok := ctx.Value(jobTrackerKey{}).(*jobTracker)
+        if !ok {
+            return ej.RunE(ctx)
+        }
+        info, _ := JobInfoFromContext(ctx)
+        run := JobRun{JobInfo: info, Start: t.c.now()}
+        t.active.Add(1)
+        defer t.active.Add(-1)
+        if t.c.onJobStart != nil {
+            t.c.onJobStart(run)
+        }
+        defer func() {
+            if r := recover(); r != nil {
+                run.Outcome = JobPanicked
+                run.Panic = r
+                run.Err = fmt.Errorf("panic: %v", r)
+                t.finish(run)
+                panic(r)
+            }
+            t.finish(run)
+        }()
+        if err := ej.RunE(ctx); err != nil {
+            run.Outcome = JobFailed
+            run.Err = err
+            return err
+        }
+        return nil
+    })
+}
+
+// finish 记录结束的运行并触发结束钩子。
+func (t *jobTracker) finish(run JobRun) {
+    run.End = t.c.now()
+    run.Duration = run.End.Sub(run.Start)
+    t.history.add(run)
+    if t.c.onJobFinish != nil {
+        t.c.onJobFinish(run)
+    }
+}
+
+// jobRunKey 任务上下文中 jobRunState 的键。
+type jobRunKey struct{}
+
+// jobRunState Cron 调度的一次运行的状态。
+type jobRunState struct {
+    skipped atomic.Bool // 运行是否被包装器跳过。
+}
+
+// reportSkipped 报告任务上下文对应的本次运行被跳过。
+func reportSkipped(ctx context.Context) {
+    if state, ok := ctx.Value(jobRunKey{}).(*jobRunState); ok {
+        state.skipped.Store(true)
+    }
+    t, ok := ctx.Value(jobTrackerKey{}).(*jobTracker)
+    if !ok {
+        return
+    }
+    info, _ := JobInfoFromContext(ctx)
+    run := JobRun{JobInfo: info, Start: t.c.now(), Outcome: JobSkipped}
+    t.history.add(run)
+    if t.c.onJobSkipped != nil {
+        t.c.onJobSkipped(run)
+    }
+}
@@ -0,0 +1,94 @@
+package gcron
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestJobHistoryLimit(t *testing.T) {
+    h := &jobHistory{limit: 2}
+    for i := 1; i <= 3; i++ {
+        h.add(JobRun{JobInfo: JobInfo{ID: EntryID(i)}})
+    }
+    runs := h.list()
+    if len(runs) != 2 || runs[0].ID != 2 || runs[1].ID != 3 {
+        t.Errorf("expected the last 2 runs, got %v", runs)
+    }
+}
+
+func TestJobHooks(t *testing.T) {
+    var (
+        mu       sync.Mutex
+        started  = map[string]int{}
+        finished = map[string][]JobRun{}
+        skipped  = map[string]int{}
+    )
+    cron := New(
+        WithParser(secondParser),
+        WithChain(Recover(), SkipIfStillRunning()),
+        WithOnJobStart(func(run JobRun) {
+            mu.Lock()
+            defer mu.Unlock()
+            started[run.Name]++
+        }),
+        WithOnJobFinish(func(run JobRun) {
+            mu.Lock()
+            defer mu.Unlock()
+            finished[run.Name] = append(finished[run.Name], run)
+        }),
+        WithOnJobSkipped(func(run JobRun) {
+            mu.Lock()
+            defer mu.Unlock()
+            skipped[run.Name]++
+        }),
+    )
+    cron.AddFunc("* * * * * ?", func() {}, WithEntryName("ok"))
+    cron.AddFunc("* * * * * ?", func() { panic("boom") }, WithEntryName("panic"))
+    slow, _ := cron.AddFunc("* * * * * ?", func() { time.Sleep(1500 * time.Millisecond) }, WithEntryName("slow"))
+    cron.Start()
+    time.Sleep(2 * OneSecond)
+    <-cron.Stop(context.Background()).Done()
+
+    mu.Lock()
+    defer mu.Unlock()
+    if started["ok"] == 0 || len(finished["ok"]) != started["ok"] || finished["ok"][0].Outcome != JobSucceeded {
+        t.Errorf("unexpected ok runs: started %d, finished %v", started["ok"], finished["ok"])
+    }
+    if runs := finished["panic"]; len(runs) == 0 || runs[0].Outcome != JobPanicked || runs[0].Panic != "boom" || runs[0].Err == nil {
+        t.Errorf("unexpected panic runs: %v", runs)
+    }
+    if runs := finished["slow"]; len(runs) == 0 || runs[0].Duration < 1500*time.Millisecond {
+        t.Errorf("unexpected slow runs: %v", runs)
+    }
+    if skipped["slow"] == 0 {
+        t.Error("expected slow job to be skipped while still running")
+    }
+
+    history := cron.Entry(slow).History
+    if len(history) != len(finished["slow"])+skipped["slow"] {
+        t.Fatalf("unexpected slow history: %v", history)
+    }
+    for _, run := range history {
+        if run.ID != slow || run.Scheduled.IsZero() {
+            t.Errorf("unexpected run info: %+v", run)
+        }
+    }
+}
+
+func TestHistoryRecordsJobError(t *testing.T) {
+    cron := newWithSeconds()
+    id, _ := cron.AddErrorFunc("* * * * * ?", func(ctx context.Context) error {
+        return errors.New("failed")
+    })
+    cron.Start()
+    defer cron.Stop(context.Background())
+    time.Sleep(OneSecond)
+
+    history := cron.Entry(id).History
+    if len(history) == 0 || history[0].Outcome != JobFailed || history[0].Err == nil {
+        t.Errorf("expected failed run recorded, got %+v", history)
+    }
+}
@@ -0,0 +1,160 @@
+package gcron
+
+import (
+    "encoding/json"
+    "net/http"
+    "strconv"
+    "time"
+)
+
+// Handler 以 JSON 提供 Cron 运行状态的 http.Handler，挂载到子路径时请配合 http.StripPrefix 使用。
+//
+//	GET  /                     Cron 运行状态及全部条目
+//	GET  /entries/{id}         指定条目
+//	POST /entries/{id}/trigger 立即运行指定条目
+type Handler struct {
+    c   *Cron
+    mux *http.ServeMux
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler 新建一个展示并操作 c 的 Handler。
+func NewHandler(c *Cron) *Handler {
+    h := &Handler{c: c, mux: http.NewServeMux()}
+    h.mux.HandleFunc("GET /{$}", h.status)
+    h.mux.HandleFunc("GET /entries/{id}", h.entry)
+    h.mux.HandleFunc("POST /entries/{id}/trigger", h.trigger)
+    return h
+}
+
+// ServeHTTP 实现 http.Handler。
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    h.mux.ServeHTTP(w, r)
+}
+
+// statusView Cron 运行状态的 JSON 表示。
+type statusView struct {
+    Running bool        `json:"running"`
+    Entries []entryView `json:"entries"`
+}
+
+// entryView 条目的 JSON 表示。
+type entryView struct {
+    ID         EntryID    `json:"id"`
+    Name       string     `json:"name,omitempty"`
+    Spec       string     `json:"spec,omitempty"`
+    Next       *time.Time `json:"next,omitempty"`
+    Prev       *time.Time `json:"prev,omitempty"`
+    Paused     bool       `json:"paused"`
+    Running    int        `json:"running"`
+    DependsOn  []EntryID  `json:"depends_on,omitempty"`
+    Dependents []EntryID  `json:"dependents,omitempty"`
+    History    []runView  `json:"history,omitempty"`
+}
+
+// runView 运行记录的 JSON 表示。
+type runView struct {
+    Scheduled time.Time  `json:"scheduled"`
+    Start     time.Time  `json:"start"`
+    End       *time.Time `json:"end,omitempty"`
+    Duration  string     `json:"duration"`
+    Outcome   string     `json:"outcome"`
+    Error     string     `json:"error,omitempty"`
+}
+
+// timeOrNil 零时间返回 nil，以便在 JSON 中省略。
+func timeOrNil(t time.Time) *time.Time {
+    if t.IsZero() {
+        return nil
+    }
+    return &t
+}
+
+// newEntryView 返回条目的 JSON 表示。
+func newEntryView(e Entry) entryView {
+    v := entryView{
+        ID:         e.ID,
+        Name:       e.Name,
+        Spec:       e.Spec,
+        Next:       timeOrNil(e.Next),
+        Prev:       timeOrNil(e.Prev),
+        Paused:     e.Paused,
+        Running:    e.Running,
+        DependsOn:  e.DependsOn,
+        Dependents: e.Dependents,
+    }
+    for _, run := range e.History {
+        rv := runView{
+            Scheduled: run.Scheduled,
+            Start:     run.Start,
+            End:       timeOrNil(run.End),
+            Duration:  run.Duration.String(),
+            Outcome:   run.Outcome.String(),
+        }
+        if run.Err != nil {
+            rv.Error = run.Err.Error()
+        }
+        v.History = append(v.History, rv)
+    }
+    return v
+}
+
+// status 返回 Cron 运行状态及全部条目。
+func (h *Handler) status(w http.ResponseWriter, _ *http.Request) {
+    entries := h.c.Entries()
+    status := statusView{Running: h.c.IsRunning(), Entries: make([]entryView, len(entries))}
+    for i, e := range entries {
+        status.Entries[i] = newEntryView(e)
+    }
+    writeJSON(w, http.StatusOK, status)
+}
+
+// entry 返回指定条目。
+func (h *Handler) entry(w http.ResponseWriter, r *http.Request) {
+    e, ok := h.lookup(w, r)
+    if !ok {
+        return
+    }
+    writeJSON(w, http.StatusOK, newEntryView(e))
+}
+
+// trigger 立即运行指定条目。
+func (h *Handler) trigger(w http.ResponseWriter, r *http.Request) {
+    e, ok := h.lookup(w, r)
+    if !ok {
+        return
+    }
+    if err := h.c.Trigger(e.ID); err != nil {
+        writeError(w, http.StatusConflict, err.Error())
+        return
+    }
+    writeJSON(w, http.StatusAccepted, newEntryView(h.c.Entry(e.ID)))
+}
+
+// lookup 返回请求路径中 id 对应的条目，找不到时写入错误响应。
+func (h *Handler) lookup(w http.ResponseWriter, r *http.Request) (Entry, bool) {
+    id, err := strconv.Atoi(r.PathValue("id"))
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "invalid entry id")
+        return Entry{}, false
+    }
+    e := h.c.Entry(EntryID(id))
+    if !e.Valid() {
+        writeError(w, http.StatusNotFound, "entry not found")
+        return Entry{}, false
+    }
+    return e, true
+}
+
+// writeJSON 以 JSON 写入响应。
+func writeJSON(w http.ResponseWriter, code int, v any) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(code)
+    _ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 以 JSON 写入错误响应。
+func writeError(w http.ResponseWriter, code int, msg string) {
+    writeJSON(w, code, map[string]string{"error": msg})
+}
@@ -0,0 +1,132 @@
+package gcron
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestTrigger(t *testing.T) {
+    cron := New()
+    ran := make(chan struct{}, 1)
+    id, _ := cron.AddFunc("0 0 1 1 *", func() { ran <- struct{}{} })
+    if err := cron.Trigger(id); err == nil {
+        t.Error("expected error when cron is not running")
+    }
+
+    cron.Start()
+    defer cron.Stop(context.Background())
+    next := cron.Entry(id).Next
+    if err := cron.Trigger(id); err != nil {
+        t.Fatal(err)
+    }
+    expectSignal(t, ran, "expected triggered run")
+    if e := cron.Entry(id); !e.Next.Equal(next) || e.Prev.IsZero() {
+        t.Errorf("expected Next unchanged and Prev set, got next %v prev %v", e.Next, e.Prev)
+    }
+    if err := cron.Trigger(99); err == nil {
+        t.Error("expected error for unknown entry")
+    }
+}
+
+func TestTriggerExpiring(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    tests := []struct {
+        name     string
+        schedule Schedule
+    }{
+        {"at", At(start.Add(time.Hour))},
+        {"bounded", Bounded(time.Time{}, time.Time{}, 1)(Every(time.Hour))},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            clock := NewFakeClock(start)
+            cron := New(WithClock(clock), WithLocation(time.UTC))
+            ran := make(chan struct{}, 2)
+            id := cron.Schedule(test.schedule, FuncJob(func() { ran <- struct{}{} }))
+            cron.Start()
+            defer cron.Stop(context.Background())
+
+            if err := cron.Trigger(id); err != nil {
+                t.Fatal(err)
+            }
+            expectSignal(t, ran, "expected triggered run")
+            if e := cron.Entry(id); e.ID != 0 {
+                t.Errorf("expected exhausted entry removed, got next %v", e.Next)
+            }
+            clock.Advance(time.Hour)
+            expectNoSignal(t, ran, "expected no scheduled run after trigger used up the schedule")
+        })
+    }
+}
+
+func TestHandler(t *testing.T) {
+    cron := New()
+    started, release := make(chan struct{}, 1), make(chan struct{})
+    id := cron.Schedule(OnDemand(), blockingJob(started, release), WithEntryName("report"))
+    cron.Start()
+    defer cron.Stop(context.Background())
+    server := httptest.NewServer(http.StripPrefix("/cron", NewHandler(cron)))
+    defer server.Close()
+
+    resp, err := http.Post(server.URL+"/cron/entries/1/trigger", "", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp.Body.Close()
+    if resp.StatusCode != http.StatusAccepted {
+        t.Fatalf("expected 202, got %d", resp.StatusCode)
+    }
+    expectSignal(t, started, "expected triggered run")
+
+    var status struct {
+        Running bool
+        Entries []struct {
+            ID      EntryID
+            Name    string
+            Next    *time.Time
+            Running int
+        }
+    }
+    resp, err = http.Get(server.URL + "/cron/")
+    if err != nil {
+        t.Fatal(err)
+    }
+    err = json.NewDecoder(resp.Body).Decode(&status)
+    resp.Body.Close()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !status.Running || len(status.Entries) != 1 {
+        t.Fatalf("unexpected status %+v", status)
+    }
+    if e := status.Entries[0]; e.ID != id || e.Name != "report" || e.Next != nil || e.Running != 1 {
+        t.Errorf("unexpected entry %+v", e)
+    }
+    close(release)
+
+    tests := []struct {
+        method, path string
+        code         int
+    }{
+        {http.MethodGet, "/cron/entries/1", http.StatusOK},
+        {http.MethodGet, "/cron/entries/2", http.StatusNotFound},
+        {http.MethodGet, "/cron/entries/x", http.StatusBadRequest},
+        {http.MethodPost, "/cron/entries/2/trigger", http.StatusNotFound},
+        {http.MethodGet, "/cron/entries/1/trigger", http.StatusMethodNotAllowed},
+    }
+    for _, test := range tests {
+        req, _ := http.NewRequest(test.method, server.URL+test.path, nil)
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            t.Fatal(err)
+        }
+        resp.Body.Close()
+        if resp.StatusCode != test.code {
+            t.Errorf("%s %s: expected %d, got %d", test.method, test.path, test.code, resp.StatusCode)
+        }
+    }
+}
@@ -0,0 +1,166 @@
+package gcron
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "time"
+)
+
+// ICalCalendar 排除 iCalendar（RFC 5545）文件中事件所覆盖日期的日历。
+// 只读取 VEVENT 的 DTSTART、DTEND 和 RRULE 中的 FREQ=YEARLY，事件日期按文件中书写的日期判断，不做时区换算。
+type ICalCalendar struct {
+    days   map[date]struct{}
+    yearly map[yearlyDay]int // 每年重复的日期及其开始年份
+}
+
+// yearlyDay 每年重复的月、日。
+type yearlyDay struct {
+    month time.Month
+    day   int
+}
+
+// LoadICalCalendar 从 iCalendar 文件加载日历。
+func LoadICalCalendar(path string) (*ICalCalendar, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+    return ParseICal(f)
+}
+
+// ParseICal 从 r 中读取 iCalendar 数据并返回日历。
+func ParseICal(r io.Reader) (*ICalCalendar, error) {
+    lines, err := unfoldICal(r)
+    if err != nil {
+        return nil, err
+    }
+
+    c := &ICalCalendar{days: make(map[date]struct{}), yearly: make(map[yearlyDay]int)}
+    var (
+        inEvent    bool
+        start, end string
+        yearly     bool
+    )
+    for _, line := range lines {
+        name, value, ok := splitICalLine(line)
+        if !ok {
+            continue
+        }
+        switch {
+        case name == "BEGIN" && value == "VEVENT":
+            inEvent, start, end, yearly = true, "", "", false
+        case name == "END" && value == "VEVENT":
+            if !inEvent {
+                return nil, fmt.Errorf("unexpected END:VEVENT")
+            }
+            inEvent = false
+            if err = c.addEvent(start, end, yearly); err != nil {
+                return nil, err
+            }
+        case !inEvent:
+        case name == "DTSTART":
+            start = value
+        case name == "DTEND":
+            end = value
+        case name == "RRULE":
+            yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+        }
+    }
+    if inEvent {
+        return nil, fmt.Errorf("missing END:VEVENT")
+    }
+    return c, nil
+}
+
+// Excluded 如果 t 所在的日期被任一事件覆盖，则返回 true。
+func (c *ICalCalendar) Excluded(t time.Time) bool {
+    d := dateOf(t)
+    if _, ok := c.days[d]; ok {
+        return true
+    }
+    from, ok := c.yearly[yearlyDay{d.month, d.day}]
+    return ok && d.year >= from
+}
+
+// addEvent 排除事件覆盖的日期。全天事件的 DTEND 不包含在内，没有 DTEND 时事件只覆盖开始当天。
+func (c *ICalCalendar) addEvent(start, end string, yearly bool) error {
+    if start == "" {
+        return fmt.Errorf("event missing DTSTART")
+    }
+    first, _, err := parseICalDate(start)
+    if err != nil {
+        return err
+    }
+    last := first
+    if end != "" {
+        var allDay bool
+        if last, allDay, err = parseICalDate(end); err != nil {
+            return err
+        }
+        if allDay || last.Hour() == 0 && last.Minute() == 0 && last.Second() == 0 {
+            last = last.AddDate(0, 0, -1)
+        }
+        if last.Before(first) {
+            last = first
+        }
+    }
+    for t := first; !t.After(last); t = t.AddDate(0, 0, 1) {
+        d := dateOf(t)
+        if !yearly {
+            c.days[d] = struct{}{}
+            continue
+        }
+        key := yearlyDay{d.month, d.day}
+        if from, ok := c.yearly[key]; !ok || d.year < from {
+            c.yearly[key] = d.year
+        }
+    }
+    return nil
+}
+
+// unfoldICal 读取所有内容行，并将以空格或制表符开头的折叠行拼接到上一行。
+func unfoldICal(r io.Reader) ([]string, error) {
+    var lines []string
+    scanner := bufio.NewScanner(r)
+    for scanner.Scan() {
+        line := strings.TrimRight(scanner.Text(), "\r")
+        if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+            lines[len(lines)-1] += line[1:]
+            continue
+        }
+        lines = append(lines, line)
+    }
+    return lines, scanner.Err()
+}
+
+// splitICalLine 将内容行拆分为大写的属性名和值，忽略属性参数。
+func splitICalLine(line string) (name, value string, ok bool) {
+    colon := strings.Index(line, ":")
+    if colon < 0 {
+        return "", "", false
+    }
+    name = line[:colon]
+    if i := strings.Index(name, ";"); i >= 0 {
+        name = name[:i]
+    }
+    return strings.ToUpper(strings.TrimSpace(name)), strings.TrimSpace(line[colon+1:]), true
+}
+
+// parseICalDate 解析 DATE（20240101）或 DATE-TIME（20240101T090000、20240101T090000Z）值。
+func parseICalDate(value string) (t time.Time, allDay bool, err error) {
+    value = strings.TrimSuffix(value, "Z")
+    if len(value) == len("20060102") {
+        t, err = time.Parse("20060102", value)
+        allDay = true
+    } else {
+        t, err = time.Parse("20060102T150405", value)
+    }
+    if err != nil {
+        return time.Time{}, false, fmt.Errorf("invalid iCalendar date %q", value)
+    }
+    return t, allDay, nil
+}
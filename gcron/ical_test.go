@@ -0,0 +1,76 @@
+package gcron
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+const holidaysICal = "BEGIN:VCALENDAR\r\n" +
+    "VERSION:2.0\r\n" +
+    "BEGIN:VEVENT\r\n" +
+    "SUMMARY:National\r\n" +
+    " Day\r\n" +
+    "DTSTART;VALUE=DATE:20241001\r\n" +
+    "DTEND;VALUE=DATE:20241004\r\n" +
+    "END:VEVENT\r\n" +
+    "BEGIN:VEVENT\r\n" +
+    "SUMMARY:New Year\r\n" +
+    "DTSTART;VALUE=DATE:20240101\r\n" +
+    "RRULE:FREQ=YEARLY\r\n" +
+    "END:VEVENT\r\n" +
+    "BEGIN:VEVENT\r\n" +
+    "SUMMARY:Maintenance\r\n" +
+    "DTSTART;TZID=Asia/Shanghai:20240615T090000\r\n" +
+    "DTEND;TZID=Asia/Shanghai:20240615T120000\r\n" +
+    "END:VEVENT\r\n" +
+    "END:VCALENDAR\r\n"
+
+func TestLoadICalCalendar(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "holidays.ics")
+    if err := os.WriteFile(path, []byte(holidaysICal), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    calendar, err := LoadICalCalendar(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        date     string
+        excluded bool
+    }{
+        {"2024-09-30", false},
+        {"2024-10-01", true},
+        {"2024-10-03", true},
+        {"2024-10-04", false},
+        {"2023-01-01", false},
+        {"2024-01-01", true},
+        {"2030-01-01", true},
+        {"2030-01-02", false},
+        {"2024-06-15", true},
+        {"2024-06-16", false},
+    }
+    for _, test := range tests {
+        day, _ := time.Parse("2006-01-02", test.date)
+        if actual := calendar.Excluded(day.Add(12 * time.Hour)); actual != test.excluded {
+            t.Errorf("Excluded(%s) = %v, expected %v", test.date, actual, test.excluded)
+        }
+    }
+}
+
+func TestParseICalErrors(t *testing.T) {
+    tests := []string{
+        "BEGIN:VEVENT\nDTSTART:20240101\n",
+        "BEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n",
+        "BEGIN:VEVENT\nDTSTART:2024-01-01\nEND:VEVENT\n",
+        "END:VEVENT\n",
+    }
+    for _, test := range tests {
+        if _, err := ParseICal(strings.NewReader(test)); err == nil {
+            t.Errorf("expected error for %q", test)
+        }
+    }
+}
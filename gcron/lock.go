@@ -0,0 +1,144 @@
+package gcron
+
+import (
+    "context"
+    "errors"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Locker 跨实例互斥的锁接口，可替换为 Redis、etcd 等实现。
+type Locker interface {
+    // Lock 尝试获取 key 对应的锁，锁在 ttl 后自动过期，返回是否获取成功。
+    Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+    // Unlock 释放 key 对应的锁。
+    Unlock(ctx context.Context, key string) error
+}
+
+var (
+    _ Locker = (*MemoryLocker)(nil)
+    _ Locker = (*FileLocker)(nil)
+)
+
+// MemoryLocker 基于内存的锁，仅在单个进程内互斥，适用于本地测试。
+type MemoryLocker struct {
+    mu    sync.Mutex
+    locks map[string]time.Time
+}
+
+// NewMemoryLocker 新建一个基于内存的锁。
+func NewMemoryLocker() *MemoryLocker {
+    return &MemoryLocker{locks: make(map[string]time.Time)}
+}
+
+// Lock 尝试获取 key 对应的锁，锁在 ttl 后自动过期，返回是否获取成功。
+func (l *MemoryLocker) Lock(_ context.Context, key string, ttl time.Duration) (bool, error) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    now := time.Now()
+    for k, expire := range l.locks {
+        if !expire.After(now) {
+            delete(l.locks, k)
+        }
+    }
+    if _, ok := l.locks[key]; ok {
+        return false, nil
+    }
+    l.locks[key] = now.Add(ttl)
+    return true, nil
+}
+
+// Unlock 释放 key 对应的锁。
+func (l *MemoryLocker) Unlock(_ context.Context, key string) error {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    delete(l.locks, key)
+    return nil
+}
+
+// lockFileExt 锁文件扩展名。
+const lockFileExt = ".lock"
+
+// FileLocker 基于锁文件的锁，在共享同一目录的多个进程间互斥。
+// 每个键对应目录下的一个锁文件，文件内容为过期时间。
+type FileLocker struct {
+    dir string
+}
+
+// NewFileLocker 新建一个在 dir 目录下创建锁文件的锁。
+func NewFileLocker(dir string) *FileLocker {
+    return &FileLocker{dir: dir}
+}
+
+// Lock 尝试获取 key 对应的锁，锁在 ttl 后自动过期，返回是否获取成功。
+// 获取前会清理目录下已过期的锁文件。
+func (l *FileLocker) Lock(_ context.Context, key string, ttl time.Duration) (bool, error) {
+    if err := os.MkdirAll(l.dir, 0o755); err != nil {
+        return false, err
+    }
+    l.removeExpired()
+
+    now := time.Now()
+    f, err := os.OpenFile(l.path(key), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+    if errors.Is(err, fs.ErrExist) {
+        return false, nil
+    }
+    if err != nil {
+        return false, err
+    }
+    _, err = f.WriteString(strconv.FormatInt(now.Add(ttl).UnixNano(), 10))
+    if closeErr := f.Close(); err == nil {
+        err = closeErr
+    }
+    if err != nil {
+        _ = os.Remove(f.Name())
+        return false, err
+    }
+    return true, nil
+}
+
+// Unlock 释放 key 对应的锁。
+func (l *FileLocker) Unlock(_ context.Context, key string) error {
+    err := os.Remove(l.path(key))
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil
+    }
+    return err
+}
+
+// removeExpired 删除目录下已过期的锁文件。
+func (l *FileLocker) removeExpired() {
+    files, err := filepath.Glob(filepath.Join(l.dir, "*"+lockFileExt))
+    if err != nil {
+        return
+    }
+    now := time.Now().UnixNano()
+    for _, file := range files {
+        data, err := os.ReadFile(file)
+        if err != nil {
+            continue
+        }
+        // 内容尚未写入或无法解析的锁文件可能正在创建，不做处理。
+        expire, err := strconv.ParseInt(string(data), 10, 64)
+        if err == nil && expire <= now {
+            _ = os.Remove(file)
+        }
+    }
+}
+
+// path 返回 key 对应的锁文件路径，文件名中不安全的字符将被替换为下划线。
+func (l *FileLocker) path(key string) string {
+    name := strings.Map(func(r rune) rune {
+        switch {
+        case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '@':
+            return r
+        }
+        return '_'
+    }, key)
+    return filepath.Join(l.dir, name+lockFileExt)
+}
@@ -0,0 +1,71 @@
+package gcron
+
+import (
+    "context"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func testLocker(t *testing.T, locker Locker) {
+    ctx := context.Background()
+
+    if ok, err := locker.Lock(ctx, "job@1", time.Minute); !ok || err != nil {
+        t.Fatalf("expected lock acquired, got %v %v", ok, err)
+    }
+    if ok, err := locker.Lock(ctx, "job@1", time.Minute); ok || err != nil {
+        t.Fatalf("expected lock held, got %v %v", ok, err)
+    }
+    if ok, err := locker.Lock(ctx, "job@2", time.Minute); !ok || err != nil {
+        t.Fatalf("expected other key acquired, got %v %v", ok, err)
+    }
+    if err := locker.Unlock(ctx, "job@1"); err != nil {
+        t.Fatal(err)
+    }
+    if ok, err := locker.Lock(ctx, "job@1", 10*time.Millisecond); !ok || err != nil {
+        t.Fatalf("expected lock acquired after unlock, got %v %v", ok, err)
+    }
+    time.Sleep(20 * time.Millisecond)
+    if ok, err := locker.Lock(ctx, "job@1", time.Minute); !ok || err != nil {
+        t.Fatalf("expected lock acquired after expiry, got %v %v", ok, err)
+    }
+}
+
+func TestMemoryLocker(t *testing.T) {
+    testLocker(t, NewMemoryLocker())
+}
+
+func TestFileLocker(t *testing.T) {
+    testLocker(t, NewFileLocker(t.TempDir()))
+}
+
+func TestChainSingletonAcrossInstances(t *testing.T) {
+    var calls int64
+    job := FuncJob(func() { atomic.AddInt64(&calls, 1) })
+    locker := NewFileLocker(t.TempDir())
+
+    // 两个实例各自包装同一任务，共享同一个锁。
+    instance1 := toContextJob(NewChain(SingletonAcrossInstances(locker)).Then(job))
+    instance2 := toContextJob(NewChain(SingletonAcrossInstances(locker)).Then(job))
+
+    scheduled := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    ctx := context.WithValue(context.Background(), jobInfoKey{}, JobInfo{Name: "job", Scheduled: scheduled})
+    instance1.RunContext(ctx)
+    instance2.RunContext(ctx)
+    if c := atomic.LoadInt64(&calls); c != 1 {
+        t.Errorf("expected job run once for the same scheduled time, got %d", c)
+    }
+
+    ctx = context.WithValue(context.Background(), jobInfoKey{}, JobInfo{Name: "job", Scheduled: scheduled.Add(time.Minute)})
+    instance2.RunContext(ctx)
+    if c := atomic.LoadInt64(&calls); c != 2 {
+        t.Errorf("expected job run for the next scheduled time, got %d", c)
+    }
+
+    // 间隔小于一秒的调度，同一秒内的每次运行使用不同的锁。
+    ctx = context.WithValue(context.Background(), jobInfoKey{}, JobInfo{Name: "job", Scheduled: scheduled.Add(time.Minute + 250*time.Millisecond)})
+    instance1.RunContext(ctx)
+    if c := atomic.LoadInt64(&calls); c != 3 {
+        t.Errorf("expected job run for a sub-second scheduled time, got %d", c)
+    }
+}
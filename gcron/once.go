@@ -0,0 +1,32 @@
+package gcron
+
+import "time"
+
+// OnceSchedule 只在指定时间激活一次的计划，激活后 Cron 会自动移除条目。
+type OnceSchedule struct {
+    At time.Time
+}
+
+// At 返回在 t 激活一次的计划，t 早于添加条目的时间时不会激活。
+func At(t time.Time) OnceSchedule {
+    return OnceSchedule{At: t}
+}
+
+// After 返回从现在起 d 之后激活一次的计划。
+// 时间以调用时的 time.Now() 为准，使用 WithClock 时请改用 At 并以 Clock 的当前时间计算。
+func After(d time.Duration) OnceSchedule {
+    return At(time.Now().Add(d))
+}
+
+// Next 返回下次应该的运行时间，已过激活时间时返回零时间。
+func (schedule OnceSchedule) Next(t time.Time) time.Time {
+    if schedule.At.After(t) {
+        return schedule.At
+    }
+    return time.Time{}
+}
+
+// Expired 激活过一次或已过激活时间后返回 true。
+func (schedule OnceSchedule) Expired(now time.Time, runs int) bool {
+    return runs > 0 || !schedule.At.After(now)
+}
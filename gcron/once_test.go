@@ -0,0 +1,51 @@
+package gcron
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+func TestOnceSchedule(t *testing.T) {
+    at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+    sched := At(at)
+    if next := sched.Next(at.Add(-time.Minute)); !next.Equal(at) {
+        t.Errorf("expected %v, got %v", at, next)
+    }
+    if next := sched.Next(at); !next.IsZero() {
+        t.Errorf("expected zero time, got %v", next)
+    }
+    if sched.Expired(at.Add(-time.Minute), 0) || !sched.Expired(at.Add(-time.Minute), 1) || !sched.Expired(at, 0) {
+        t.Error("unexpected expiry")
+    }
+
+    if next := After(time.Hour).Next(time.Now()); next.Before(time.Now().Add(59 * time.Minute)) {
+        t.Errorf("expected about an hour from now, got %v", next)
+    }
+}
+
+func TestOnceEntryRemovedAfterRun(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    clock := NewFakeClock(start)
+    cron := New(WithClock(clock), WithLocation(time.UTC))
+    fired := make(chan struct{}, 2)
+    id := cron.Schedule(At(start.Add(time.Minute)), FuncJob(func() { fired <- struct{}{} }))
+    past := cron.Schedule(At(start.Add(-time.Minute)), FuncJob(func() { fired <- struct{}{} }))
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    clock.BlockUntil(1)
+    if cron.Entry(past).ID != 0 {
+        t.Error("expected entry in the past removed")
+    }
+    clock.Advance(time.Minute)
+    select {
+    case <-fired:
+    case <-time.After(time.Second):
+        t.Fatal("expected one-shot run")
+    }
+    clock.BlockUntil(1)
+    if cron.Entry(id).ID != 0 || len(cron.Entries()) != 0 {
+        t.Errorf("expected entry removed, got %v", cron.Entries())
+    }
+}
@@ -44,3 +44,113 @@ func WithLogger(logger *glog.Helper) Option {
         c.logger = logger
     }
 }
+
+// WithClock 使用提供的时间来源代替 time 包，测试时可传入 FakeClock 手动推进时间。
+func WithClock(clock Clock) Option {
+    return func(c *Cron) {
+        c.clock = clock
+    }
+}
+
+// WithWorkerPool 使用 size 个常驻 worker 运行任务，最多再排队 queue 个待运行的任务，代替每次运行新建 goroutine。
+// worker 全忙且队列已满时按 overflow 处理新的运行，并通过日志报告池已饱和。
+func WithWorkerPool(size, queue int, overflow OverflowPolicy) Option {
+    return func(c *Cron) {
+        if size <= 0 {
+            size = 1
+        }
+        if queue < 0 {
+            queue = 0
+        }
+        c.pool = &workerPool{size: size, queue: queue, overflow: overflow}
+    }
+}
+
+// WithJobStore 使用提供的任务存储持久化具名条目的状态，使调度在进程重启后得以延续。
+func WithJobStore(store JobStore) Option {
+    return func(c *Cron) {
+        c.store = store
+    }
+}
+
+// WithMisfirePolicy 指定启动时或运行期间添加具名条目时，对停机期间错过的运行的处理策略，仅在配置了 JobStore 时生效。
+func WithMisfirePolicy(policy MisfirePolicy) Option {
+    return func(c *Cron) {
+        c.misfire = policy
+    }
+}
+
+// WithDSTPolicy 设置夏令时切换当天的处理策略，作用于解析器未显式设置策略的计划。
+func WithDSTPolicy(policy DSTPolicy) Option {
+    return func(c *Cron) {
+        c.dst = policy
+    }
+}
+
+// WithHistoryLimit 指定每个条目保留的运行记录数，默认 10，小于等于 0 时不记录。
+func WithHistoryLimit(n int) Option {
+    return func(c *Cron) {
+        c.historyLimit = n
+    }
+}
+
+// WithOnJobStart 指定任务开始运行时调用的钩子。
+// 钩子在任务所在的 goroutine 中调用，需要并发安全。
+func WithOnJobStart(fn func(run JobRun)) Option {
+    return func(c *Cron) {
+        c.onJobStart = fn
+    }
+}
+
+// WithOnJobFinish 指定任务结束时调用的钩子，可从 run 获取耗时、错误或 panic。
+// 钩子在任务所在的 goroutine 中调用，需要并发安全。
+func WithOnJobFinish(fn func(run JobRun)) Option {
+    return func(c *Cron) {
+        c.onJobFinish = fn
+    }
+}
+
+// WithOnJobSkipped 指定任务被包装器跳过时调用的钩子，例如 SkipIfStillRunning。
+// 钩子在任务所在的 goroutine 中调用，需要并发安全。
+func WithOnJobSkipped(fn func(run JobRun)) Option {
+    return func(c *Cron) {
+        c.onJobSkipped = fn
+    }
+}
+
+// EntryOption 表示对添加到 Cron 的条目的修改。
+type EntryOption func(*Entry)
+
+// WithEntryName 指定条目名称。
+// 名称在 Cron 实例内应唯一，并在进程重启后保持不变。
+func WithEntryName(name string) EntryOption {
+    return func(e *Entry) {
+        e.Name = name
+    }
+}
+
+// WithScheduleWrappers 指定应用于条目调度的装饰器，例如 Jitter。
+// 装饰顺序同 Chain：WithScheduleWrappers(m1, m2) 相当于 m1(m2(schedule))。
+func WithScheduleWrappers(wrappers ...ScheduleWrapper) EntryOption {
+    return func(e *Entry) {
+        e.wrappers = append(e.wrappers, wrappers...)
+    }
+}
+
+// WithMaxConcurrency 限制条目同时运行的任务数，达到上限时跳过本次运行，n 小于等于 0 时不限制。
+func WithMaxConcurrency(n int) EntryOption {
+    return func(e *Entry) {
+        if n > 0 {
+            e.slots = make(chan struct{}, n)
+        } else {
+            e.slots = nil
+        }
+    }
+}
+
+// withSpec 记录创建条目的调度规范。
+func withSpec(spec string) EntryOption {
+    return func(e *Entry) {
+        e.Spec = spec
+    }
+}
@@ -24,6 +24,20 @@ func TestWithParser(t *testing.T) {
     }
 }
 
+func TestWithDSTPolicy(t *testing.T) {
+    c := New(WithDSTPolicy(DSTRunOnce))
+    id, _ := c.AddFunc("30 2 * * *", func() {})
+    if s := c.Entry(id).Schedule.(*SpecSchedule); s.DST != DSTRunOnce {
+        t.Errorf("expected run-once policy, got %v", s.DST)
+    }
+
+    c = New(WithParser(standardParser.WithDSTPolicy(DSTSkip)), WithDSTPolicy(DSTRunBoth))
+    id, _ = c.AddFunc("30 2 * * *", func() {})
+    if s := c.Entry(id).Schedule.(*SpecSchedule); s.DST != DSTSkip {
+        t.Errorf("expected parser policy kept, got %v", s.DST)
+    }
+}
+
 func TestWithVerboseLogger(t *testing.T) {
     var buf syncWriter
     logger := glog.NewHelper(glog.NewStdLogger(&buf))
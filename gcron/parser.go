@@ -6,6 +6,8 @@ import (
     "strconv"
     "strings"
     "time"
+
+    "github.com/camry/g/v2/encoding/ghash"
 )
 
 // ParseOption 用于创建解析器的配置选项。
@@ -24,6 +26,12 @@ const (
     Dow                                    // 周，默认 *
     DowOptional                            // 可选周，默认 *
     Descriptor                             // 允许使用 @monthly、@weekly 等描述符。
+    Year                                   // 年，默认 *
+    YearOptional                           // 可选年，默认 *
+    Last                                   // 允许在日字段使用 L、L-n，在周字段使用 nL（周仍以 0-6 表示周日到周六）。
+    NearestWeekday                         // 允许在日字段使用 nW，与 Last 同时启用时允许 LW。
+    NthWeekday                             // 允许在周字段使用 n#k。
+    Hash                                   // 允许使用 H、H/n、H(a-b)、H(a-b)/n，取值由条目名称的哈希值决定。
 )
 
 var places = []ParseOption{
@@ -33,6 +41,7 @@ var places = []ParseOption{
     Dom,
     Month,
     Dow,
+    Year,
 }
 
 var defaults = []string{
@@ -42,11 +51,14 @@ var defaults = []string{
     "*",
     "*",
     "*",
+    "*",
 }
 
 // Parser 可以配置的自定义解析器。
 type Parser struct {
-    options ParseOption
+    options   ParseOption
+    dst       DSTPolicy
+    calendars *map[string]Calendar // 使用指针以保持 Parser 可比较
 }
 
 // NewParser 使用自定义选项创建解析器。
@@ -66,6 +78,10 @@ type Parser struct {
 //	// 同上，只是使 Dow 可选
 //	specParser := NewParser(Dom | Month | DowOptional)
 //	sched, err := specParser.Parse("15 */3")
+//
+//	// Quartz 风格的解析器，支持 L、W、# 和可选的年字段
+//	specParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional | Last | NearestWeekday | NthWeekday)
+//	sched, err := specParser.Parse("0 0 10 ? * 5L 2024-2030")
 func NewParser(options ParseOption) Parser {
     optionals := 0
     if options&DowOptional > 0 {
@@ -74,72 +90,181 @@ func NewParser(options ParseOption) Parser {
     if options&SecondOptional > 0 {
         optionals++
     }
+    if options&YearOptional > 0 {
+        optionals++
+    }
     if optionals > 1 {
         panic("multiple optionals may not be configured")
     }
-    return Parser{options}
+    return Parser{options: options}
+}
+
+// WithDSTPolicy 返回使用指定夏令时策略的解析器副本，其解析出的计划按该策略处理夏令时切换。
+func (p Parser) WithDSTPolicy(policy DSTPolicy) Parser {
+    p.dst = policy
+    return p
+}
+
+// WithCalendar 返回注册了具名日历的解析器副本。
+// 规范以 CAL=name 开头时，解析出的计划会跳过该日历所排除的日期，例如 "CAL=holidays TZ=Asia/Shanghai 0 18 * * 1-5"。
+func (p Parser) WithCalendar(name string, calendar Calendar) Parser {
+    calendars := map[string]Calendar{name: calendar}
+    if p.calendars != nil {
+        for k, v := range *p.calendars {
+            if k != name {
+                calendars[k] = v
+            }
+        }
+    }
+    p.calendars = &calendars
+    return p
+}
+
+// HashScheduleParser 支持 H 哈希字段的调度规范解析器接口。
+// Cron 添加具名条目时使用条目名称作为 key，使同名条目在各实例上得到相同且分散的调度。
+type HashScheduleParser interface {
+    ScheduleParser
+    ParseHash(spec, key string) (Schedule, error)
 }
 
 // Parse 返回代表指定规范的新 crontab 计划。
 // 如果规范无效，则返回描述性错误。
 // 它接受由 NewParser 配置的 crontab 规范和特性。
+// 规范中的 H 以空字符串为 key 取值，需要按条目分散时请使用 ParseHash。
 func (p Parser) Parse(spec string) (Schedule, error) {
+    return p.ParseHash(spec, "")
+}
+
+// ParseHash 同 Parse，但规范中的 H 字段以 key 的哈希值取值。
+// 相同的 key 总是得到相同的调度，不同的 key 则被分散到字段范围内。
+func (p Parser) ParseHash(spec, key string) (Schedule, error) {
     if len(spec) == 0 {
-        return nil, fmt.Errorf("empty spec string")
+        return nil, newSpecError(spec, -1, "empty spec string")
+    }
+
+    // 提取日历（如果存在）
+    var (
+        calendar Calendar
+        start    int
+    )
+    if strings.HasPrefix(spec, "CAL=") {
+        i := strings.Index(spec, " ")
+        if i < 0 {
+            return nil, newSpecError(spec, len(spec), "missing schedule after calendar: %s", spec)
+        }
+        name := spec[len("CAL="):i]
+        if p.calendars != nil {
+            calendar = (*p.calendars)[name]
+        }
+        if calendar == nil {
+            return nil, newSpecError(spec, len("CAL="), "unknown calendar %s", name)
+        }
+        start = skipSpace(spec, i)
+    }
+
+    schedule, err := p.parse(spec, start, key)
+    if err != nil || calendar == nil {
+        return schedule, err
     }
+    return CalendarSchedule{Schedule: schedule, Calendar: calendar}, nil
+}
+
+// parse 解析从 spec[start:] 开始、不含日历前缀的规范，错误中的偏移相对于整个 spec。
+func (p Parser) parse(spec string, start int, key string) (Schedule, error) {
 
     // 提取时区（如果存在）
     var loc = time.Local
-    if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+    if rest := spec[start:]; strings.HasPrefix(rest, "TZ=") || strings.HasPrefix(rest, "CRON_TZ=") {
         var err error
-        i := strings.Index(spec, " ")
-        eq := strings.Index(spec, "=")
-        if loc, err = time.LoadLocation(spec[eq+1 : i]); err != nil {
-            return nil, fmt.Errorf("provided bad location %s: %v", spec[eq+1:i], err)
+        i := strings.Index(rest, " ")
+        eq := strings.Index(rest, "=")
+        if i < 0 {
+            return nil, newSpecError(spec, len(spec), "missing schedule after location: %s", rest)
+        }
+        if loc, err = time.LoadLocation(rest[eq+1 : i]); err != nil {
+            return nil, newSpecError(spec, start+eq+1, "provided bad location %s: %v", rest[eq+1:i], err)
         }
-        spec = strings.TrimSpace(spec[i:])
+        start = skipSpace(spec, start+i)
     }
+    body := strings.TrimSpace(spec[start:])
 
     // 处理命名计划（描述符），如果已配置
-    if strings.HasPrefix(spec, "@") {
+    if strings.HasPrefix(body, "@") {
         if p.options&Descriptor == 0 {
-            return nil, fmt.Errorf("parser does not accept descriptors: %v", spec)
+            return nil, newSpecError(spec, start, "parser does not accept descriptors: %v", body)
         }
-        return parseDescriptor(spec, loc)
+        schedule, err := parseDescriptor(body, loc)
+        if err != nil {
+            return nil, newSpecError(spec, start, "%v", err)
+        }
+        if s, ok := schedule.(*SpecSchedule); ok {
+            s.DST = p.dst
+        }
+        return schedule, nil
     }
 
-    // 在空白处拆分。
-    fields := strings.Fields(spec)
+    // 在空白处拆分，并记录各字段在规范中的偏移。
+    tokens, offsets := fieldsIndex(spec, start)
 
     // 验证并填写任何省略或可选的字段
-    var err error
-    fields, err = normalizeFields(fields, p.options)
+    fields, index, err := normalizeFieldsIndex(tokens, p.options)
     if err != nil {
-        return nil, err
+        return nil, newSpecError(spec, start, "%v", err)
+    }
+    fieldErr := func(place int, err error) error {
+        if n := index[place]; n >= 0 {
+            return newFieldError(spec, place, offsets[n], tokens[n], err)
+        }
+        return newFieldError(spec, place, -1, "", err)
     }
 
-    field := func(field string, r bounds) uint64 {
-        if err != nil {
+    // 将 H 字段展开为普通表达式
+    if p.options&Hash > 0 {
+        for i := range fields {
+            if fields[i], err = expandHash(fields[i], i, key); err != nil {
+                return nil, fieldErr(i, err)
+            }
+        }
+    }
+
+    field := func(place int, r bounds) uint64 {
+        if err != nil || fields[place] == "" {
             return 0
         }
         var bits uint64
-        bits, err = getField(field, r)
+        if bits, err = getField(fields[place], r); err != nil {
+            err = fieldErr(place, err)
+        }
         return bits
     }
 
+    // 先取出日、周字段中的扩展表达式，其余部分按位集解析。
+    var ext specExtension
+    if len(fields) > 6 {
+        if err = ext.parseYears(fields[6]); err != nil {
+            return nil, fieldErr(6, err)
+        }
+    }
+    if fields[3], err = ext.parseDom(fields[3], p.options); err != nil {
+        return nil, fieldErr(3, err)
+    }
+    if fields[5], err = ext.parseDow(fields[5], p.options); err != nil {
+        return nil, fieldErr(5, err)
+    }
+
     var (
-        second     = field(fields[0], seconds)
-        minute     = field(fields[1], minutes)
-        hour       = field(fields[2], hours)
-        dayofmonth = field(fields[3], dom)
-        month      = field(fields[4], months)
-        dayofweek  = field(fields[5], dow)
+        second     = field(0, seconds)
+        minute     = field(1, minutes)
+        hour       = field(2, hours)
+        dayofmonth = field(3, dom)
+        month      = field(4, months)
+        dayofweek  = field(5, dow)
     )
     if err != nil {
         return nil, err
     }
 
-    return &SpecSchedule{
+    schedule := &SpecSchedule{
         Second:   second,
         Minute:   minute,
         Hour:     hour,
@@ -147,13 +272,24 @@ func (p Parser) Parse(spec string) (Schedule, error) {
         Month:    month,
         Dow:      dayofweek,
         Location: loc,
-    }, nil
+        DST:      p.dst,
+    }
+    if !ext.isZero() {
+        schedule.ext = &ext
+    }
+    return schedule, nil
 }
 
 // normalizeFields 获取时间字段的子集并返回完整的集合，其中填充了未设置字段的默认值（零）。
 //
 // 作为执行此功能的一部分，它还验证提供的字段是否与配置的选项兼容。
 func normalizeFields(fields []string, options ParseOption) ([]string, error) {
+    expanded, _, err := normalizeFieldsIndex(fields, options)
+    return expanded, err
+}
+
+// normalizeFieldsIndex 同 normalizeFields，同时返回每个字段在输入中的下标，填充默认值的字段为 -1。
+func normalizeFieldsIndex(fields []string, options ParseOption) ([]string, []int, error) {
     // 验证选项并将其字段添加到选项
     optionals := 0
     if options&SecondOptional > 0 {
@@ -164,8 +300,12 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
         options |= Dow
         optionals++
     }
+    if options&YearOptional > 0 {
+        options |= Year
+        optionals++
+    }
     if optionals > 1 {
-        return nil, fmt.Errorf("multiple optionals may not be configured")
+        return nil, nil, fmt.Errorf("multiple optionals may not be configured")
     }
 
     // 弄清楚我们需要多少个字段
@@ -180,34 +320,89 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
     // 验证字段数
     if count := len(fields); count < min || count > max {
         if min == max {
-            return nil, fmt.Errorf("expected exactly %d fields, found %d: %s", min, count, fields)
+            return nil, nil, fmt.Errorf("expected exactly %d fields, found %d: %s", min, count, fields)
         }
-        return nil, fmt.Errorf("expected %d to %d fields, found %d: %s", min, max, count, fields)
+        return nil, nil, fmt.Errorf("expected %d to %d fields, found %d: %s", min, max, count, fields)
+    }
+
+    index := make([]int, len(fields))
+    for i := range index {
+        index[i] = i
     }
 
     // 如果未提供，则填充可选字段
     if min < max && len(fields) == min {
+        last := len(fields) - 1
         switch {
+        case options&DowOptional > 0 && options&Year > 0:
+            fields = append(fields[:last:last], defaults[5], fields[last])
+            index = append(index[:last:last], -1, index[last])
         case options&DowOptional > 0:
             fields = append(fields, defaults[5]) // TODO: improve access to default
+            index = append(index, -1)
+        case options&YearOptional > 0:
+            fields = append(fields, defaults[6])
+            index = append(index, -1)
         case options&SecondOptional > 0:
             fields = append([]string{defaults[0]}, fields...)
+            index = append([]int{-1}, index...)
         default:
-            return nil, fmt.Errorf("unknown optional field")
+            return nil, nil, fmt.Errorf("unknown optional field")
         }
     }
 
-    // 使用默认值填充不属于选项的所有字段
+    // 使用默认值填充不属于选项的所有字段，未配置年字段时不输出年字段
     n := 0
-    expandedFields := make([]string, len(places))
+    expandedPlaces := places
+    if options&Year == 0 {
+        expandedPlaces = places[:len(places)-1]
+    }
+    expandedFields := make([]string, len(expandedPlaces))
+    expandedIndex := make([]int, len(expandedPlaces))
     copy(expandedFields, defaults)
-    for i, place := range places {
+    for i, place := range expandedPlaces {
+        expandedIndex[i] = -1
         if options&place > 0 {
             expandedFields[i] = fields[n]
+            expandedIndex[i] = index[n]
             n++
         }
     }
-    return expandedFields, nil
+    return expandedFields, expandedIndex, nil
+}
+
+// fieldsIndex 从 spec[start:] 中按空白拆分字段，并返回各字段在 spec 中的字节偏移。
+func fieldsIndex(spec string, start int) ([]string, []int) {
+    var (
+        fields  []string
+        offsets []int
+    )
+    for i := start; i < len(spec); {
+        i = skipSpace(spec, i)
+        j := i
+        for j < len(spec) && !isSpace(spec[j]) {
+            j++
+        }
+        if j > i {
+            fields = append(fields, spec[i:j])
+            offsets = append(offsets, i)
+        }
+        i = j
+    }
+    return fields, offsets
+}
+
+// skipSpace 返回 spec 中从 i 开始第一个非空白字符的位置。
+func skipSpace(spec string, i int) int {
+    for i < len(spec) && isSpace(spec[i]) {
+        i++
+    }
+    return i
+}
+
+// isSpace 如果 c 是 ASCII 空白字符，则返回 true。
+func isSpace(c byte) bool {
+    return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
 }
 
 var standardParser = NewParser(
@@ -222,10 +417,29 @@ var standardParser = NewParser(
 // 它接受
 // - 标准 crontab 规范，例如 “* * * * ？”
 // - 描述符，例如 “@midnight”、“@每 1 小时 30 分”
+// - 毫秒精度的 “@fixed-rate 250ms”、“@fixed-delay 500ms”，“@every” 只接受整秒
 func ParseStandard(standardSpec string) (Schedule, error) {
     return standardParser.Parse(standardSpec)
 }
 
+// Validate 检查规范能否被解析，并且从当前时间起至少还会激活一次，用于校验配置。
+// 出错时可通过 ParseErrorDetailOf 获取出错的字段和位置。
+func (p Parser) Validate(spec string) error {
+    schedule, err := p.Parse(spec)
+    if err != nil {
+        return err
+    }
+    if schedule.Next(time.Now()).IsZero() {
+        return newSpecError(spec, -1, "schedule never fires: %s", spec)
+    }
+    return nil
+}
+
+// Validate 使用标准解析器检查规范，参见 Parser.Validate。
+func Validate(spec string) error {
+    return standardParser.Validate(spec)
+}
+
 // getField 返回一个 Int，其位设置表示该字段表示的所有时间或错误解析字段值。
 // “字段”是逗号分隔的“范围”列表。
 func getField(field string, r bounds) (uint64, error) {
@@ -234,7 +448,7 @@ func getField(field string, r bounds) (uint64, error) {
     for _, expr := range ranges {
         bit, err := getRange(expr, r)
         if err != nil {
-            return bits, err
+            return bits, wrapExpr(expr, err)
         }
         bits |= bit
     }
@@ -312,6 +526,226 @@ func getRange(expr string, r bounds) (uint64, error) {
     return getBits(start, end, step) | extra, nil
 }
 
+// parseDom 取出日字段中 L、L-n、LW、nW 形式的扩展表达式，返回剩余的普通表达式。
+func (e *specExtension) parseDom(field string, options ParseOption) (_ string, err error) {
+    var rest []string
+    var expr string
+    defer func() { err = wrapExpr(expr, err) }()
+    for _, expr = range strings.Split(field, ",") {
+        upper := strings.ToUpper(expr)
+        switch {
+        case options&Last > 0 && upper == "L":
+            e.lastDom |= 1
+        case options&Last > 0 && strings.HasPrefix(upper, "L-"):
+            offset, err := mustParseInt(expr[2:])
+            if err != nil {
+                return "", err
+            }
+            if offset >= dom.max {
+                return "", fmt.Errorf("offset from last day (%d) above maximum (%d): %s", offset, dom.max-1, expr)
+            }
+            e.lastDom |= 1 << offset
+        case options&Last > 0 && options&NearestWeekday > 0 && upper == "LW":
+            e.lastWeekday = true
+        case options&NearestWeekday > 0 && len(upper) > 1 && strings.HasSuffix(upper, "W"):
+            day, err := mustParseInt(expr[:len(expr)-1])
+            if err != nil {
+                return "", err
+            }
+            if day < dom.min || day > dom.max {
+                return "", fmt.Errorf("nearest weekday (%d) out of range (%d-%d): %s", day, dom.min, dom.max, expr)
+            }
+            e.nearestWeekday |= 1 << day
+        default:
+            rest = append(rest, expr)
+        }
+    }
+    return strings.Join(rest, ","), nil
+}
+
+// parseDow 取出周字段中 L、nL、n#k 形式的扩展表达式，返回剩余的普通表达式。
+func (e *specExtension) parseDow(field string, options ParseOption) (_ string, err error) {
+    var rest []string
+    var expr string
+    defer func() { err = wrapExpr(expr, err) }()
+    for _, expr = range strings.Split(field, ",") {
+        upper := strings.ToUpper(expr)
+        switch {
+        case options&Last > 0 && upper == "L":
+            e.lastDow |= 1 << dow.max
+        case options&Last > 0 && len(upper) > 1 && strings.HasSuffix(upper, "L"):
+            weekday, err := parseIntOrName(expr[:len(expr)-1], dow.names)
+            if err != nil {
+                return "", err
+            }
+            if weekday > dow.max {
+                return "", fmt.Errorf("weekday (%d) above maximum (%d): %s", weekday, dow.max, expr)
+            }
+            e.lastDow |= 1 << weekday
+        case options&NthWeekday > 0 && strings.Contains(expr, "#"):
+            parts := strings.Split(expr, "#")
+            if len(parts) != 2 {
+                return "", fmt.Errorf("too many hashes: %s", expr)
+            }
+            weekday, err := parseIntOrName(parts[0], dow.names)
+            if err != nil {
+                return "", err
+            }
+            nth, err := mustParseInt(parts[1])
+            if err != nil {
+                return "", err
+            }
+            if weekday > dow.max {
+                return "", fmt.Errorf("weekday (%d) above maximum (%d): %s", weekday, dow.max, expr)
+            }
+            if nth < 1 || nth > 5 {
+                return "", fmt.Errorf("nth weekday (%d) out of range (1-5): %s", nth, expr)
+            }
+            e.nthDow[weekday] |= 1 << nth
+        default:
+            rest = append(rest, expr)
+        }
+    }
+    return strings.Join(rest, ","), nil
+}
+
+// parseYears 解析年字段，“*”或“?”表示不限制年份。
+func (e *specExtension) parseYears(field string) (err error) {
+    if field == "*" || field == "?" {
+        return nil
+    }
+    set := make([]bool, years.max-years.min+1)
+    var expr string
+    defer func() { err = wrapExpr(expr, err) }()
+    for _, expr = range strings.Split(field, ",") {
+        var (
+            start, end, step uint
+            rangeAndStep     = strings.Split(expr, "/")
+            lowAndHigh       = strings.Split(rangeAndStep[0], "-")
+        )
+        if lowAndHigh[0] == "*" {
+            start, end = years.min, years.max
+        } else {
+            if start, err = mustParseInt(lowAndHigh[0]); err != nil {
+                return err
+            }
+            switch len(lowAndHigh) {
+            case 1:
+                end = start
+            case 2:
+                if end, err = mustParseInt(lowAndHigh[1]); err != nil {
+                    return err
+                }
+            default:
+                return fmt.Errorf("too many hyphens: %s", expr)
+            }
+        }
+        switch len(rangeAndStep) {
+        case 1:
+            step = 1
+        case 2:
+            if step, err = mustParseInt(rangeAndStep[1]); err != nil {
+                return err
+            }
+            if len(lowAndHigh) == 1 {
+                end = years.max
+            }
+        default:
+            return fmt.Errorf("too many slashes: %s", expr)
+        }
+        if start < years.min {
+            return fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, years.min, expr)
+        }
+        if end > years.max {
+            return fmt.Errorf("end of range (%d) above maximum (%d): %s", end, years.max, expr)
+        }
+        if start > end {
+            return fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
+        }
+        if step == 0 {
+            return fmt.Errorf("step of range should be a positive number: %s", expr)
+        }
+        for y := start; y <= end; y += step {
+            set[y-years.min] = true
+        }
+    }
+    e.years = []int{}
+    for i, ok := range set {
+        if ok {
+            e.years = append(e.years, int(years.min)+i)
+        }
+    }
+    return nil
+}
+
+// hashBounds 各字段 H 的取值范围，日字段只取 1-28 以保证每月都能命中。
+var hashBounds = []bounds{seconds, minutes, hours, {1, 28, nil}, months, dow, years}
+
+// expandHash 将字段中的 H 表达式按 key 和字段位置的哈希值展开为普通表达式：
+//
+//	H         => 范围内的一个固定值
+//	H/n       => 从范围内固定偏移开始、步长为 n 的序列
+//	H(a-b)    => [a, b] 内的一个固定值
+//	H(a-b)/n  => 从 [a, b] 内固定偏移开始、步长为 n 的序列
+func expandHash(field string, place int, key string) (_ string, err error) {
+    if !strings.ContainsAny(field, "Hh") {
+        return field, nil
+    }
+    hash := ghash.BKDR64([]byte(key + "/" + strconv.Itoa(place)))
+    exprs := strings.Split(field, ",")
+    var expr string
+    defer func() { err = wrapExpr(expr, err) }()
+    for i := range exprs {
+        expr = exprs[i]
+        if expr == "" || (expr[0] != 'H' && expr[0] != 'h') {
+            continue
+        }
+        r := hashBounds[place]
+        rangeAndStep := strings.Split(expr[1:], "/")
+        if rng := rangeAndStep[0]; rng != "" {
+            if !strings.HasPrefix(rng, "(") || !strings.HasSuffix(rng, ")") {
+                return "", fmt.Errorf("malformed hash range: %s", expr)
+            }
+            lowAndHigh := strings.Split(rng[1:len(rng)-1], "-")
+            if len(lowAndHigh) != 2 {
+                return "", fmt.Errorf("malformed hash range: %s", expr)
+            }
+            low, err := mustParseInt(lowAndHigh[0])
+            if err != nil {
+                return "", err
+            }
+            high, err := mustParseInt(lowAndHigh[1])
+            if err != nil {
+                return "", err
+            }
+            if low > high {
+                return "", fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", low, high, expr)
+            }
+            r = bounds{low, high, nil}
+        }
+        switch len(rangeAndStep) {
+        case 1:
+            exprs[i] = strconv.FormatUint(uint64(r.min)+hash%uint64(r.max-r.min+1), 10)
+        case 2:
+            step, err := mustParseInt(rangeAndStep[1])
+            if err != nil {
+                return "", err
+            }
+            if step == 0 {
+                return "", fmt.Errorf("step of range should be a positive number: %s", expr)
+            }
+            offset := uint(hash % uint64(step))
+            if r.min+offset > r.max {
+                offset = 0
+            }
+            exprs[i] = fmt.Sprintf("%d-%d/%d", r.min+offset, r.max, step)
+        default:
+            return "", fmt.Errorf("too many slashes: %s", expr)
+        }
+    }
+    return strings.Join(exprs, ","), nil
+}
+
 // parseIntOrName 返回 expr 中包含的（可能命名的）整数。
 func parseIntOrName(expr string, names map[string]uint) (uint, error) {
     if names != nil {
@@ -414,14 +848,48 @@ func parseDescriptor(descriptor string, loc *time.Location) (Schedule, error) {
 
     }
 
-    const every = "@every "
-    if strings.HasPrefix(descriptor, every) {
-        duration, err := time.ParseDuration(descriptor[len(every):])
+    const (
+        every      = "@every "
+        fixedRate  = "@fixed-rate "
+        fixedDelay = "@fixed-delay "
+    )
+    switch {
+    case strings.HasPrefix(descriptor, every):
+        duration, err := parseInterval(descriptor, every)
         if err != nil {
-            return nil, fmt.Errorf("failed to parse duration %s: %s", descriptor, err)
+            return nil, err
+        }
+        if duration%time.Second != 0 {
+            return nil, fmt.Errorf("@every only supports whole seconds, use @fixed-rate or @fixed-delay for sub-second durations: %s", descriptor)
         }
         return Every(duration), nil
+
+    case strings.HasPrefix(descriptor, fixedRate):
+        duration, err := parseInterval(descriptor, fixedRate)
+        if err != nil {
+            return nil, err
+        }
+        return FixedRate(duration), nil
+
+    case strings.HasPrefix(descriptor, fixedDelay):
+        duration, err := parseInterval(descriptor, fixedDelay)
+        if err != nil {
+            return nil, err
+        }
+        return FixedDelay(duration), nil
     }
 
     return nil, fmt.Errorf("unrecognized descriptor: %s", descriptor)
 }
+
+// parseInterval 解析描述符 prefix 之后的时间间隔，间隔必须为正且精确到毫秒。
+func parseInterval(descriptor, prefix string) (time.Duration, error) {
+    duration, err := time.ParseDuration(descriptor[len(prefix):])
+    if err != nil {
+        return 0, fmt.Errorf("failed to parse duration %s: %s", descriptor, err)
+    }
+    if duration <= 0 || duration%time.Millisecond != 0 {
+        return 0, fmt.Errorf("duration must be a positive whole number of milliseconds: %s", descriptor)
+    }
+    return duration, nil
+}
@@ -2,9 +2,13 @@ package gcron
 
 import (
     "reflect"
+    "strconv"
     "strings"
     "testing"
     "time"
+
+    "github.com/camry/g/v2/gerrors/gcode"
+    "github.com/camry/g/v2/gerrors/gerror"
 )
 
 var secondParser = NewParser(Second | Minute | Hour | Dom | Month | DowOptional | Descriptor)
@@ -122,9 +126,16 @@ func TestParseScheduleErrors(t *testing.T) {
     var tests = []struct{ expr, err string }{
         {"* 5 j * * *", "failed to parse int from"},
         {"@every Xm", "failed to parse duration"},
+        {"@every 250ms", "@every only supports whole seconds"},
+        {"@every 1500ms", "@every only supports whole seconds"},
+        {"@every 0s", "positive whole number of milliseconds"},
+        {"@fixed-rate 1500us", "positive whole number of milliseconds"},
+        {"@fixed-delay -1s", "positive whole number of milliseconds"},
         {"@unrecognized", "unrecognized descriptor"},
         {"* * * *", "expected 5 to 6 fields"},
         {"", "empty spec string"},
+        {"0 0 0 1 january *", "failed to parse int from"},
+        {"0 0 0 * * monday", "failed to parse int from"},
     }
     for _, c := range tests {
         actual, err := secondParser.Parse(c.expr)
@@ -150,6 +161,8 @@ func TestParseSchedule(t *testing.T) {
         {standardParser, "CRON_TZ=UTC  5 * * * *", every5min(time.UTC)},
         {secondParser, "CRON_TZ=Asia/Tokyo 0 5 * * * *", every5min(tokyo)},
         {secondParser, "@every 5m", ConstantDelaySchedule{5 * time.Minute}},
+        {secondParser, "@fixed-rate 250ms", FixedRateSchedule{250 * time.Millisecond}},
+        {standardParser, "@fixed-delay 1.5s", FixedDelaySchedule{1500 * time.Millisecond}},
         {secondParser, "@midnight", midnight(time.Local)},
         {secondParser, "TZ=UTC  @midnight", midnight(time.UTC)},
         {secondParser, "TZ=Asia/Tokyo @midnight", midnight(tokyo)},
@@ -320,7 +333,7 @@ func TestStandardSpecSchedule(t *testing.T) {
     }{
         {
             expr:     "5 * * * *",
-            expected: &SpecSchedule{1 << seconds.min, 1 << 5, all(hours), all(dom), all(months), all(dow), time.Local},
+            expected: &SpecSchedule{Second: 1 << seconds.min, Minute: 1 << 5, Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow), Location: time.Local},
         },
         {
             expr:     "@every 5m",
@@ -358,16 +371,126 @@ func TestNoDescriptorParser(t *testing.T) {
     }
 }
 
+func TestParseExtendedErrors(t *testing.T) {
+    quartzParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional | Last | NearestWeekday | NthWeekday)
+    tests := []struct {
+        parser Parser
+        spec   string
+        err    string
+    }{
+        {secondParser, "0 0 0 L * ?", "failed to parse int from"},
+        {secondParser, "0 0 0 15W * ?", "failed to parse int from"},
+        {secondParser, "0 0 0 ? * 2#2", "failed to parse int from"},
+        {secondParser, "0 0 0 1 1 ? 2030", "expected 5 to 6 fields"},
+        {quartzParser, "0 0 0 L-31 * ?", "above maximum"},
+        {quartzParser, "0 0 0 32W * ?", "out of range"},
+        {quartzParser, "0 0 0 ? * 2#6", "out of range"},
+        {quartzParser, "0 0 0 ? * 2#1#1", "too many hashes"},
+        {quartzParser, "0 0 0 ? * 8L", "above maximum"},
+        {quartzParser, "0 0 0 1 1 ? 1969", "below minimum"},
+        {quartzParser, "0 0 0 1 1 ? 2100", "above maximum"},
+    }
+    for _, c := range tests {
+        _, err := c.parser.Parse(c.spec)
+        if err == nil || !strings.Contains(err.Error(), c.err) {
+            t.Errorf("%s => expected %v, got %v", c.spec, c.err, err)
+        }
+    }
+}
+
+func TestParseExtended(t *testing.T) {
+    parser := NewParser(Minute | Hour | Dom | Month | DowOptional | Year | Last | NearestWeekday | NthWeekday)
+    sched, err := parser.Parse("0 0 L-1,LW,10W * 2024-2026/2")
+    if err != nil {
+        t.Fatal(err)
+    }
+    expected := &specExtension{lastDom: 1 << 1, lastWeekday: true, nearestWeekday: 1 << 10, years: []int{2024, 2026}}
+    if actual := sched.(*SpecSchedule); !reflect.DeepEqual(actual.ext, expected) || actual.Dow != all(dow) || actual.Dom != 0 {
+        t.Errorf("unexpected schedule %+v, ext %+v", actual, actual.ext)
+    }
+
+    sched, err = parser.Parse("0 0 ? * 5L,MON#1 2024")
+    if err != nil {
+        t.Fatal(err)
+    }
+    expected = &specExtension{lastDow: 1 << 5, years: []int{2024}}
+    expected.nthDow[1] = 1 << 1
+    if actual := sched.(*SpecSchedule); !reflect.DeepEqual(actual.ext, expected) {
+        t.Errorf("unexpected ext %+v", actual.ext)
+    }
+
+    sched, err = parser.Parse("0 0 1 * *")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if sched.(*SpecSchedule).ext != nil {
+        t.Error("expected plain spec without extension")
+    }
+}
+
+func TestExpandHash(t *testing.T) {
+    tests := []struct {
+        field string
+        place int
+        check func(string) bool
+    }{
+        {"H", 1, func(s string) bool { n, err := strconv.Atoi(s); return err == nil && n >= 0 && n <= 59 }},
+        {"H", 3, func(s string) bool { n, err := strconv.Atoi(s); return err == nil && n >= 1 && n <= 28 }},
+        {"H(10-12)", 2, func(s string) bool { return s == "10" || s == "11" || s == "12" }},
+        {"H/15", 1, func(s string) bool { return strings.HasSuffix(s, "-59/15") }},
+        {"H(0-29)/10", 1, func(s string) bool { return strings.HasSuffix(s, "-29/10") }},
+        {"1,H(5-5)", 1, func(s string) bool { return s == "1,5" }},
+        {"*/5", 1, func(s string) bool { return s == "*/5" }},
+    }
+    for _, c := range tests {
+        actual, err := expandHash(c.field, c.place, "job")
+        if err != nil || !c.check(actual) {
+            t.Errorf("%s => unexpected %q, %v", c.field, actual, err)
+        }
+    }
+
+    for _, field := range []string{"H(1-)", "H(5-1)", "H/0", "H//2", "H[1-2]"} {
+        if _, err := expandHash(field, 1, "job"); err == nil {
+            t.Errorf("%s => expected an error", field)
+        }
+    }
+}
+
+func TestParseHash(t *testing.T) {
+    parser := NewParser(Minute | Hour | Dom | Month | Dow | Hash)
+    a1, err := parser.ParseHash("H H * * *", "a")
+    if err != nil {
+        t.Fatal(err)
+    }
+    a2, _ := parser.ParseHash("H H * * *", "a")
+    if !reflect.DeepEqual(a1, a2) {
+        t.Error("expected the same key to produce the same schedule")
+    }
+
+    distinct := map[uint64]bool{}
+    for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+        s, _ := parser.ParseHash("H * * * *", key)
+        distinct[s.(*SpecSchedule).Minute] = true
+    }
+    if len(distinct) < 2 {
+        t.Error("expected different keys to spread minutes")
+    }
+
+    if _, err = secondParser.Parse("0 H * * * *"); err == nil {
+        t.Error("expected an error when Hash is not enabled")
+    }
+}
+
 func every5min(loc *time.Location) *SpecSchedule {
-    return &SpecSchedule{1 << 0, 1 << 5, all(hours), all(dom), all(months), all(dow), loc}
+    return &SpecSchedule{Second: 1 << 0, Minute: 1 << 5, Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow), Location: loc}
 }
 
 func every5min5s(loc *time.Location) *SpecSchedule {
-    return &SpecSchedule{1 << 5, 1 << 5, all(hours), all(dom), all(months), all(dow), loc}
+    return &SpecSchedule{Second: 1 << 5, Minute: 1 << 5, Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow), Location: loc}
 }
 
 func midnight(loc *time.Location) *SpecSchedule {
-    return &SpecSchedule{1, 1, 1, all(dom), all(months), all(dow), loc}
+    return &SpecSchedule{Second: 1, Minute: 1, Hour: 1, Dom: all(dom), Month: all(months), Dow: all(dow), Location: loc}
 }
 
 func annual(loc *time.Location) *SpecSchedule {
@@ -381,3 +504,61 @@ func annual(loc *time.Location) *SpecSchedule {
         Location: loc,
     }
 }
+
+func TestParseErrorDetail(t *testing.T) {
+    quartz := NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional | Last | NthWeekday)
+    tests := []struct {
+        parser   Parser
+        spec     string
+        field    int
+        offset   int
+        min, max uint
+    }{
+        {standardParser, "5 1-99 * * *", 2, 2, 0, 23},
+        {standardParser, "TZ=UTC 0,5-2 * * * *", 1, 9, 0, 59},
+        {standardParser, "  */0 * * * *", 1, 2, 0, 59},
+        {secondParser, "0 0 1 1 * MON/x", 5, 10, 0, 6},
+        {NewParser(Minute | Hour | Dom | Month | Dow | SecondOptional), "0 0 32 * *", 3, 4, 1, 31},
+        {quartz, "0 0 10 ? * 5L,1#9", 5, 14, 0, 6},
+        {quartz, "0 0 10 ? * * 2024,2100", 6, 18, 1970, 2099},
+        {standardParser, "TZ=Nowhere/City 0 * * * *", -1, 3, 0, 0},
+        {standardParser, "* * *", -1, 0, 0, 0},
+        {standardParser, "@fortnightly", -1, 0, 0, 0},
+    }
+    for _, test := range tests {
+        _, err := test.parser.Parse(test.spec)
+        detail, ok := ParseErrorDetailOf(err)
+        if !ok {
+            t.Errorf("%q: expected parse error detail, got %v", test.spec, err)
+            continue
+        }
+        if gerror.Code(err).Code() != gcode.CodeInvalidParameter.Code() {
+            t.Errorf("%q: expected invalid parameter code, got %v", test.spec, gerror.Code(err))
+        }
+        if detail.Spec != test.spec || detail.Field != test.field || detail.Offset != test.offset ||
+            detail.Min != test.min || detail.Max != test.max {
+            t.Errorf("%q: unexpected detail %+v (%v)", test.spec, detail, err)
+        }
+        if test.field >= 0 && detail.Name != fieldNames[test.field] {
+            t.Errorf("%q: expected field name %q, got %q", test.spec, fieldNames[test.field], detail.Name)
+        }
+    }
+}
+
+func TestValidate(t *testing.T) {
+    tests := []struct {
+        spec  string
+        valid bool
+    }{
+        {"0 9 * * 1-5", true},
+        {"@every 1h", true},
+        {"0 0 30 2 *", false},
+        {"0 24 * * *", false},
+        {"", false},
+    }
+    for _, test := range tests {
+        if err := Validate(test.spec); (err == nil) != test.valid {
+            t.Errorf("Validate(%q) = %v, expected valid %v", test.spec, err, test.valid)
+        }
+    }
+}
@@ -0,0 +1,147 @@
+package gcron
+
+import (
+    "context"
+    "sync"
+)
+
+// OverflowPolicy 描述 worker 池已满时如何处理新的运行。
+type OverflowPolicy int
+
+const (
+    OverflowBlock     OverflowPolicy = iota // 按顺序等待空位后运行（默认），等待期间调度照常进行。
+    OverflowDrop                            // 丢弃本次运行，并记录为跳过。
+    OverflowRunInline                       // 在调度 goroutine 中直接运行，期间调度暂停，任务不能调用 Cron 的方法，否则会死锁。
+)
+
+func (p OverflowPolicy) String() string {
+    switch p {
+    case OverflowBlock:
+        return "block"
+    case OverflowDrop:
+        return "drop"
+    case OverflowRunInline:
+        return "run-inline"
+    default:
+        return ""
+    }
+}
+
+// workerPool 固定数量 worker 的任务池，最多容纳 size 个运行中和 queue 个排队中的任务。
+type workerPool struct {
+    size     int
+    queue    int
+    overflow OverflowPolicy
+
+    slots chan struct{}
+    tasks chan func()
+
+    // 等待空位的任务，由单独的 dispatch goroutine 按顺序提交，使调度 goroutine 不被阻塞。
+    mu         sync.Mutex
+    backlog    []pendingTask
+    ready      chan struct{}
+    quit       chan struct{}
+    dispatched chan struct{}
+}
+
+// pendingTask 等待空位的任务。
+type pendingTask struct {
+    ctx     context.Context
+    task    func()
+    abandon func() // 上下文取消或池停止而放弃提交时调用。
+}
+
+// start 启动 worker。
+func (p *workerPool) start() {
+    p.slots = make(chan struct{}, p.size+p.queue)
+    p.tasks = make(chan func(), p.size+p.queue)
+    p.ready = make(chan struct{}, 1)
+    p.quit = make(chan struct{})
+    p.dispatched = make(chan struct{})
+    go p.dispatch()
+    for i := 0; i < p.size; i++ {
+        go func(tasks chan func()) {
+            for task := range tasks {
+                task()
+            }
+        }(p.tasks)
+    }
+}
+
+// stop 放弃仍在等待空位的任务，并在排队的任务运行完成后停止 worker。
+func (p *workerPool) stop() {
+    close(p.quit)
+    <-p.dispatched
+    close(p.tasks)
+}
+
+// trySubmit 尝试提交任务，池已满时返回 false。
+func (p *workerPool) trySubmit(task func()) bool {
+    select {
+    case p.slots <- struct{}{}:
+        p.tasks <- p.release(task)
+        return true
+    default:
+        return false
+    }
+}
+
+// submit 提交任务，池已满时阻塞直到有空位；等待期间 ctx 被取消则放弃提交并返回 false。
+func (p *workerPool) submit(ctx context.Context, task func()) bool {
+    select {
+    case p.slots <- struct{}{}:
+        p.tasks <- p.release(task)
+        return true
+    case <-ctx.Done():
+        return false
+    }
+}
+
+// enqueue 将任务加入等待空位的队列，从不阻塞。
+func (p *workerPool) enqueue(ctx context.Context, task, abandon func()) {
+    p.mu.Lock()
+    p.backlog = append(p.backlog, pendingTask{ctx: ctx, task: task, abandon: abandon})
+    p.mu.Unlock()
+    select {
+    case p.ready <- struct{}{}:
+    default:
+    }
+}
+
+// dispatch 按顺序将等待的任务提交到池中，直到池停止。
+func (p *workerPool) dispatch() {
+    defer close(p.dispatched)
+    for {
+        select {
+        case <-p.ready:
+        case <-p.quit:
+            for _, t := range p.takeBacklog() {
+                t.abandon()
+            }
+            return
+        }
+        for _, t := range p.takeBacklog() {
+            if !p.submit(t.ctx, t.task) {
+                t.abandon()
+            }
+        }
+    }
+}
+
+// takeBacklog 取出全部等待的任务。
+func (p *workerPool) takeBacklog() []pendingTask {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    backlog := p.backlog
+    p.backlog = nil
+    return backlog
+}
+
+// release 返回运行 task 后释放空位的任务。
+func (p *workerPool) release(task func()) func() {
+    slots := p.slots
+    return func() {
+        defer func() { <-slots }()
+        task()
+    }
+}
@@ -0,0 +1,171 @@
+package gcron
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+// blockingJob 返回开始运行时通知 started、直到 release 关闭才结束的任务。
+func blockingJob(started chan<- struct{}, release <-chan struct{}) Job {
+    return FuncJob(func() {
+        started <- struct{}{}
+        <-release
+    })
+}
+
+// fireEvery 返回使用 FakeClock 每秒运行一次 job 的 Cron，以及逐秒推进时间的函数。
+func fireEvery(job Job, opts []Option, entryOpts ...EntryOption) (*Cron, func()) {
+    clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+    cron := New(append([]Option{WithClock(clock), WithLocation(time.UTC)}, opts...)...)
+    cron.Schedule(Every(time.Second), job, entryOpts...)
+    cron.Start()
+    return cron, func() {
+        clock.BlockUntil(1)
+        clock.Advance(time.Second)
+    }
+}
+
+func expectSignal(t *testing.T, ch <-chan struct{}, msg string) {
+    t.Helper()
+    select {
+    case <-ch:
+    case <-time.After(time.Second):
+        t.Fatal(msg)
+    }
+}
+
+func expectNoSignal(t *testing.T, ch <-chan struct{}, msg string) {
+    t.Helper()
+    select {
+    case <-ch:
+        t.Fatal(msg)
+    case <-time.After(50 * time.Millisecond):
+    }
+}
+
+func TestWorkerPoolOverflow(t *testing.T) {
+    t.Run("drop", func(t *testing.T) {
+        started, release, skipped := make(chan struct{}, 2), make(chan struct{}), make(chan struct{}, 1)
+        cron, fire := fireEvery(blockingJob(started, release), []Option{
+            WithWorkerPool(1, 0, OverflowDrop),
+            WithOnJobSkipped(func(JobRun) { skipped <- struct{}{} }),
+        })
+        defer cron.Stop(context.Background())
+        defer close(release)
+
+        fire()
+        expectSignal(t, started, "expected first run")
+        fire()
+        expectSignal(t, skipped, "expected second run dropped")
+        expectNoSignal(t, started, "expected no second run")
+    })
+
+    t.Run("run inline", func(t *testing.T) {
+        started, release := make(chan struct{}, 2), make(chan struct{})
+        cron, fire := fireEvery(blockingJob(started, release), []Option{WithWorkerPool(1, 0, OverflowRunInline)})
+        defer cron.Stop(context.Background())
+        defer close(release)
+
+        fire()
+        expectSignal(t, started, "expected first run")
+        fire()
+        expectSignal(t, started, "expected second run inline")
+    })
+
+    t.Run("block", func(t *testing.T) {
+        started, release := make(chan struct{}, 3), make(chan struct{})
+        cron, fire := fireEvery(blockingJob(started, release), []Option{WithWorkerPool(1, 1, OverflowBlock)})
+        defer cron.Stop(context.Background())
+
+        fire()
+        expectSignal(t, started, "expected first run")
+        fire()
+        expectNoSignal(t, started, "expected second run queued")
+        close(release)
+        expectSignal(t, started, "expected queued run after release")
+    })
+}
+
+func TestWorkerPoolStopUnblocks(t *testing.T) {
+    started := make(chan struct{}, 3)
+    job := FuncContextJob(func(ctx context.Context) {
+        started <- struct{}{}
+        <-ctx.Done()
+    })
+    cron, fire := fireEvery(job, []Option{WithWorkerPool(1, 0, OverflowBlock)})
+    fire()
+    expectSignal(t, started, "expected first run")
+    fire()
+
+    select {
+    case <-cron.Stop(context.Background()).Done():
+    case <-time.After(time.Second):
+        t.Fatal("expected stop to cancel blocked dispatch")
+    }
+}
+
+func TestMaxConcurrency(t *testing.T) {
+    started, release, skipped := make(chan struct{}, 3), make(chan struct{}), make(chan struct{}, 1)
+    cron, fire := fireEvery(blockingJob(started, release), []Option{
+        WithOnJobSkipped(func(JobRun) { skipped <- struct{}{} }),
+    }, WithMaxConcurrency(2))
+    defer cron.Stop(context.Background())
+
+    fire()
+    fire()
+    expectSignal(t, started, "expected first run")
+    expectSignal(t, started, "expected second run")
+    fire()
+    expectSignal(t, skipped, "expected third run skipped")
+    close(release)
+}
+
+func TestWorkerPoolBlockFixedDelay(t *testing.T) {
+    clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+    cron := New(WithClock(clock), WithLocation(time.UTC), WithWorkerPool(1, 0, OverflowBlock))
+    started, release, ran := make(chan struct{}, 1), make(chan struct{}), make(chan struct{}, 2)
+    cron.Schedule(FixedDelay(500*time.Millisecond), blockingJob(started, release))
+    cron.Schedule(Every(time.Second), FuncJob(func() { ran <- struct{}{} }))
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    clock.BlockUntil(1)
+    clock.Advance(500 * time.Millisecond)
+    expectSignal(t, started, "expected fixed delay run")
+    clock.BlockUntil(1)
+    clock.Advance(500 * time.Millisecond)
+    expectNoSignal(t, ran, "expected run blocked on full pool")
+
+    // 固定延迟的任务结束时，调度程序仍阻塞在提交上，任务不能反过来阻塞在通知调度程序上。
+    close(release)
+    expectSignal(t, ran, "expected blocked run after fixed delay run finished")
+    done := make(chan struct{})
+    go func() {
+        cron.Entries()
+        close(done)
+    }()
+    expectSignal(t, done, "expected scheduler to keep serving requests")
+}
+
+func TestWorkerPoolBlockCallsCron(t *testing.T) {
+    started, release, finished := make(chan struct{}, 3), make(chan struct{}), make(chan struct{}, 3)
+    var cron *Cron
+    job := FuncJob(func() {
+        started <- struct{}{}
+        <-release
+        // 下一次运行正在等待空位，任务调用 Cron 的方法不能死锁。
+        cron.Entries()
+        finished <- struct{}{}
+    })
+    cron, fire := fireEvery(job, []Option{WithWorkerPool(1, 0, OverflowBlock)})
+    defer cron.Stop(context.Background())
+
+    fire()
+    expectSignal(t, started, "expected first run")
+    fire()
+    expectNoSignal(t, started, "expected second run waiting for a slot")
+    close(release)
+    expectSignal(t, finished, "expected job calling Entries to finish")
+    expectSignal(t, started, "expected second run after the first finished")
+}
@@ -0,0 +1,154 @@
+package gcron
+
+import (
+    "math/rand/v2"
+    "time"
+)
+
+// ScheduleWrapper 用一些行为装饰指定的 Schedule。
+type ScheduleWrapper func(Schedule) Schedule
+
+// ExpiringSchedule 会过期的计划，Cron 在条目过期后自动将其移除。
+type ExpiringSchedule interface {
+    Schedule
+    // Expired 返回在 now 时刻、条目已激活 runs 次后计划是否不会再激活。
+    Expired(now time.Time, runs int) bool
+}
+
+// DelayedSchedule 从上一次运行结束时计算下一次运行时间的计划，即固定延迟。
+// Cron 在任务运行期间不调度此类条目，任务结束后以结束时间调用 Next。
+type DelayedSchedule interface {
+    Schedule
+    FixedDelay() bool
+}
+
+var (
+    _ ExpiringSchedule = OnceSchedule{}
+    _ ExpiringSchedule = BoundedSchedule{}
+    _ ExpiringSchedule = JitterSchedule{}
+    _ DelayedSchedule  = FixedDelaySchedule{}
+    _ DelayedSchedule  = BoundedSchedule{}
+    _ DelayedSchedule  = JitterSchedule{}
+)
+
+// JitterSchedule 在内部调度的每次激活时间上叠加 [0, Max) 的随机延迟，用于分散同一时刻的负载。
+// 任务收到的 JobInfo.Scheduled 为未叠加延迟的调度时间，因此各实例的 SingletonAcrossInstances 使用相同的锁。
+type JitterSchedule struct {
+    Schedule Schedule
+    Max      time.Duration
+}
+
+// Jitter 返回为调度叠加最多 max 随机延迟的装饰器。
+// max 应小于调度的间隔，否则可能错过下一次激活；用于 ConstantDelaySchedule 时延迟会逐次累积。
+func Jitter(max time.Duration) ScheduleWrapper {
+    return func(s Schedule) Schedule {
+        return JitterSchedule{Schedule: s, Max: max}
+    }
+}
+
+// Next 返回下次应该的运行时间。
+func (s JitterSchedule) Next(t time.Time) time.Time {
+    next := s.Schedule.Next(t)
+    if next.IsZero() || s.Max <= 0 {
+        return next
+    }
+    return next.Add(rand.N(s.Max))
+}
+
+// Expired 如果内部调度已过期，则返回 true。
+func (s JitterSchedule) Expired(now time.Time, runs int) bool {
+    return expired(s.Schedule, now, runs)
+}
+
+// FixedDelay 如果内部调度是固定延迟的，则返回 true。
+func (s JitterSchedule) FixedDelay() bool {
+    return fixedDelay(s.Schedule)
+}
+
+// withoutJitter 返回去掉所有 JitterSchedule 装饰后的调度，调度中含有 JitterSchedule 时返回 true。
+func withoutJitter(s Schedule) (Schedule, bool) {
+    switch v := s.(type) {
+    case JitterSchedule:
+        inner, _ := withoutJitter(v.Schedule)
+        return inner, true
+    case BoundedSchedule:
+        inner, ok := withoutJitter(v.Schedule)
+        v.Schedule = inner
+        return v, ok
+    case CalendarSchedule:
+        inner, ok := withoutJitter(v.Schedule)
+        v.Schedule = inner
+        return v, ok
+    }
+    return s, false
+}
+
+// BoundedSchedule 将内部调度限制在 [Start, End] 时间窗口内，并最多激活 MaxRuns 次。
+// Start、End 为零时间时不限制开始、结束时间，MaxRuns 小于等于 0 时不限制次数。
+type BoundedSchedule struct {
+    Schedule Schedule
+    Start    time.Time
+    End      time.Time
+    MaxRuns  int
+}
+
+// Bounded 返回将调度限制在 [start, end] 时间窗口内、最多激活 maxRuns 次的装饰器。
+// 激活次数由 Cron 统计，窗口结束或次数用尽后条目会被自动移除。
+func Bounded(start, end time.Time, maxRuns int) ScheduleWrapper {
+    return func(s Schedule) Schedule {
+        return BoundedSchedule{Schedule: s, Start: start, End: end, MaxRuns: maxRuns}
+    }
+}
+
+// Next 返回下次应该的运行时间，超出时间窗口时返回零时间。
+func (s BoundedSchedule) Next(t time.Time) time.Time {
+    if !s.Start.IsZero() && t.Before(s.Start) {
+        // 从 Start 之前开始查找，使恰好位于 Start 的激活时间也能被选中。
+        t = s.Start.Add(-time.Nanosecond)
+    }
+    next := s.Schedule.Next(t)
+    if !s.End.IsZero() && next.After(s.End) {
+        return time.Time{}
+    }
+    return next
+}
+
+// Expired 激活次数用尽、时间窗口内不再有激活时间或内部调度已过期时返回 true。
+func (s BoundedSchedule) Expired(now time.Time, runs int) bool {
+    if s.MaxRuns > 0 && runs >= s.MaxRuns {
+        return true
+    }
+    return s.Next(now).IsZero() || expired(s.Schedule, now, runs)
+}
+
+// FixedDelay 如果内部调度是固定延迟的，则返回 true。
+func (s BoundedSchedule) FixedDelay() bool {
+    return fixedDelay(s.Schedule)
+}
+
+// fixedDelay 如果调度是固定延迟的 DelayedSchedule，则返回 true。
+func fixedDelay(schedule Schedule) bool {
+    s, ok := schedule.(DelayedSchedule)
+    return ok && s.FixedDelay()
+}
+
+// expired 如果调度是 ExpiringSchedule 且已过期，则返回 true。
+func expired(schedule Schedule, now time.Time, runs int) bool {
+    s, ok := schedule.(ExpiringSchedule)
+    return ok && s.Expired(now, runs)
+}
+
+// Preview 返回调度在 (from, to] 区间内最多 n 个激活时间，to 为零时间时不限制结束时间。
+// 用于在部署前确认规范的含义。
+func Preview(schedule Schedule, from, to time.Time, n int) []time.Time {
+    var times []time.Time
+    for t := from; len(times) < n; {
+        next := schedule.Next(t)
+        if next.IsZero() || !next.After(t) || !to.IsZero() && next.After(to) {
+            break
+        }
+        times = append(times, next)
+        t = next
+    }
+    return times
+}
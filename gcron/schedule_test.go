@@ -0,0 +1,165 @@
+package gcron
+
+import (
+    "context"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestJitter(t *testing.T) {
+    base := Every(time.Minute)
+    schedule := Jitter(10 * time.Second)(base)
+    now := getTime("Mon Jul 9 14:45 2012")
+    expected := base.Next(now)
+    for i := 0; i < 100; i++ {
+        next := schedule.Next(now)
+        if next.Before(expected) || !next.Before(expected.Add(10*time.Second)) {
+            t.Fatalf("expected next in [%v, %v), got %v", expected, expected.Add(10*time.Second), next)
+        }
+    }
+
+    if next := Jitter(time.Second)(new(ZeroSchedule)).Next(now); !next.IsZero() {
+        t.Errorf("expected zero time kept, got %v", next)
+    }
+}
+
+func TestJitterSingletonAcrossInstances(t *testing.T) {
+    clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+    locker := NewMemoryLocker()
+    attempts := make(chan time.Time, 4)
+    var runs atomic.Int32
+
+    // 两个实例共享同一个锁，各自为同一条目叠加不同的随机延迟。
+    for i := 0; i < 2; i++ {
+        cron := New(WithClock(clock), WithLocation(time.UTC),
+            WithChain(SingletonAcrossInstances(locker)),
+            WithOnJobSkipped(func(run JobRun) { attempts <- run.Scheduled }),
+        )
+        cron.AddContextFunc("* * * * *", func(ctx context.Context) {
+            runs.Add(1)
+            info, _ := JobInfoFromContext(ctx)
+            attempts <- info.Scheduled
+        }, WithEntryName("refresh"), WithScheduleWrappers(Jitter(30*time.Second)))
+        cron.Start()
+        defer cron.Stop(context.Background())
+    }
+
+    clock.BlockUntil(2)
+    clock.Advance(90 * time.Second)
+    for i := 0; i < 2; i++ {
+        select {
+        case scheduled := <-attempts:
+            if want := time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC); !scheduled.Equal(want) {
+                t.Errorf("expected scheduled time %v without jitter, got %v", want, scheduled)
+            }
+        case <-time.After(time.Second):
+            t.Fatal("expected both instances to attempt the run")
+        }
+    }
+    if n := runs.Load(); n != 1 {
+        t.Errorf("expected job run once across instances, got %d", n)
+    }
+}
+
+func TestEntryScheduleWrappers(t *testing.T) {
+    cron := New(WithParser(NewParser(Minute | Hour | Dom | Month | Dow | Hash)))
+    id, err := cron.AddFunc("H * * * *", func() {}, WithEntryName("report"), WithScheduleWrappers(Jitter(time.Second)))
+    if err != nil {
+        t.Fatal(err)
+    }
+    jitter, ok := cron.Entry(id).Schedule.(JitterSchedule)
+    if !ok {
+        t.Fatalf("expected jitter schedule, got %T", cron.Entry(id).Schedule)
+    }
+    expected, _ := NewParser(Minute|Hour|Dom|Month|Dow|Hash).ParseHash("H * * * *", "report")
+    if jitter.Schedule.(*SpecSchedule).Minute != expected.(*SpecSchedule).Minute {
+        t.Error("expected entry name used as hash key")
+    }
+
+    if err = cron.Reschedule(id, "H H * * *"); err != nil {
+        t.Fatal(err)
+    }
+    if _, ok = cron.Entry(id).Schedule.(JitterSchedule); !ok {
+        t.Error("expected schedule wrappers kept after reschedule")
+    }
+}
+
+func TestPreview(t *testing.T) {
+    sched, _ := ParseStandard("0 9 * * MON-FRI")
+    from := time.Date(2024, 1, 5, 12, 0, 0, 0, time.Local)
+
+    times := Preview(sched, from, time.Time{}, 3)
+    expected := []time.Time{
+        time.Date(2024, 1, 8, 9, 0, 0, 0, time.Local),
+        time.Date(2024, 1, 9, 9, 0, 0, 0, time.Local),
+        time.Date(2024, 1, 10, 9, 0, 0, 0, time.Local),
+    }
+    if len(times) != len(expected) {
+        t.Fatalf("expected %v, got %v", expected, times)
+    }
+    for i := range expected {
+        if !times[i].Equal(expected[i]) {
+            t.Errorf("expected %v, got %v", expected[i], times[i])
+        }
+    }
+
+    if times = Preview(sched, from, time.Date(2024, 1, 9, 9, 0, 0, 0, time.Local), 10); len(times) != 2 {
+        t.Errorf("expected 2 times before end, got %v", times)
+    }
+    if times = Preview(Every(time.Hour), from, from.Add(3*time.Hour), 10); len(times) != 3 {
+        t.Errorf("expected 3 times for every hour, got %v", times)
+    }
+}
+
+func TestBoundedSchedule(t *testing.T) {
+    var (
+        start = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+        end   = start.Add(3 * time.Hour)
+        sched = Bounded(start, end, 0)(Every(time.Hour))
+    )
+    if next := sched.Next(start.Add(-time.Hour)); !next.Equal(start.Add(time.Hour - time.Second)) {
+        t.Errorf("expected first run inside window, got %v", next)
+    }
+
+    hourly, _ := ParseStandard("0 * * * *")
+    sched = Bounded(start, end, 0)(hourly)
+    times := Preview(sched, start.Add(-5*time.Hour), time.Time{}, 10)
+    if len(times) != 4 || !times[0].Equal(start) || !times[3].Equal(end) {
+        t.Errorf("expected 4 runs from start to end, got %v", times)
+    }
+
+    b := sched.(BoundedSchedule)
+    if b.Expired(start, 0) || !b.Expired(end, 0) {
+        t.Error("expected expiry at end of window")
+    }
+    b.MaxRuns = 2
+    if b.Expired(start, 1) || !b.Expired(start, 2) {
+        t.Error("expected expiry after max runs")
+    }
+}
+
+func TestBoundedEntryRemoved(t *testing.T) {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    clock := NewFakeClock(start)
+    cron := New(WithClock(clock), WithLocation(time.UTC))
+    fired := make(chan struct{}, 3)
+    cron.Schedule(Every(time.Minute), FuncJob(func() { fired <- struct{}{} }),
+        WithScheduleWrappers(Bounded(time.Time{}, time.Time{}, 2)))
+    cron.Start()
+    defer cron.Stop(context.Background())
+
+    for i := 0; i < 2; i++ {
+        clock.BlockUntil(1)
+        clock.Advance(time.Minute)
+        select {
+        case <-fired:
+        case <-time.After(time.Second):
+            t.Fatalf("expected run %d", i+1)
+        }
+    }
+    clock.BlockUntil(1)
+    if entries := cron.Entries(); len(entries) != 0 {
+        t.Errorf("expected entry removed after max runs, got %v", entries)
+    }
+}
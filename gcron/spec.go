@@ -9,6 +9,22 @@ type SpecSchedule struct {
 
     // 覆盖此计划的时区。
     Location *time.Location
+
+    // 夏令时切换当天的处理策略。
+    DST DSTPolicy
+
+    // ext Quartz 风格的扩展（L、W、#、年），普通规范为 nil，只走位集判断。
+    ext *specExtension
+}
+
+// specExtension 无法用位集表示的 Quartz 风格扩展字段。
+type specExtension struct {
+    lastDom        uint64   // L、L-n：距当月最后一天 n 天（位集，第 0 位为最后一天）。
+    lastWeekday    bool     // LW：当月最后一个工作日。
+    nearestWeekday uint64   // nW：距当月第 n 天最近的工作日（位集）。
+    lastDow        uint64   // nL：当月最后一个星期 n（位集）。
+    nthDow         [7]uint8 // n#k：当月第 k 个星期 n（按星期索引，第 k 位表示第 k 个）。
+    years          []int    // 允许的年份，升序；nil 表示不限制。
 }
 
 // bounds 提供一系列可接受的值（加上名称到值的映射）。
@@ -46,6 +62,7 @@ var (
         "fri": 5,
         "sat": 6,
     }}
+    years = bounds{1970, 2099, nil}
 )
 
 const (
@@ -77,20 +94,50 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
         t = t.In(s.Location)
     }
 
+    var next time.Time
+    if s.DST == DSTDefault || s.Hour&everyHour == everyHour {
+        next = s.next(t, loc)
+    } else {
+        next = s.nextDST(t, loc)
+    }
+    if next.IsZero() {
+        return next
+    }
+    return next.In(origLocation)
+}
+
+// next 在 loc 中逐字段查找大于 t 的下一个匹配时间，找不到时返回零时间。
+// 夏令时切换时跳过不存在的时间，重复出现的时间各匹配一次。
+func (s *SpecSchedule) next(t time.Time, loc *time.Location) time.Time {
     // 尽早开始（即将到来的第二个）。
     t = t.Add(1*time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
 
     // 此标志指示字段是否已递增。
     added := false
 
-    // 如果五年内没有找到时间，则返回零。
+    // 如果五年内（或限定年份的最后一年内）没有找到时间，则返回零。
     yearLimit := t.Year() + 5
+    if s.ext != nil && len(s.ext.years) > 0 && s.ext.years[len(s.ext.years)-1] > yearLimit {
+        yearLimit = s.ext.years[len(s.ext.years)-1]
+    }
 
 WRAP:
     if t.Year() > yearLimit {
         return time.Time{}
     }
 
+    // 查找第一个适用的年份。
+    if s.ext != nil && s.ext.years != nil {
+        year, ok := s.ext.nextYear(t.Year())
+        if !ok {
+            return time.Time{}
+        }
+        if year != t.Year() {
+            added = true
+            t = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+        }
+    }
+
     // 查找第一个适用的月份。
     // 如果是这个月，那么什么都不做。
     for 1<<uint(t.Month())&s.Month == 0 {
@@ -170,7 +217,7 @@ WRAP:
         }
     }
 
-    return t.In(origLocation)
+    return t
 }
 
 // dayMatches 如果指定时间满足计划的星期几和每月几日的限制，则返回 true。
@@ -179,8 +226,85 @@ func dayMatches(s *SpecSchedule, t time.Time) bool {
         domMatch = 1<<uint(t.Day())&s.Dom > 0
         dowMatch = 1<<uint(t.Weekday())&s.Dow > 0
     )
+    if s.ext != nil {
+        domMatch = domMatch || s.ext.domMatches(t)
+        dowMatch = dowMatch || s.ext.dowMatches(t)
+    }
     if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
         return domMatch && dowMatch
     }
     return domMatch || dowMatch
 }
+
+// isZero 如果没有设置任何扩展字段，则返回 true。
+func (e *specExtension) isZero() bool {
+    return e.lastDom == 0 && !e.lastWeekday && e.nearestWeekday == 0 &&
+        e.lastDow == 0 && e.nthDow == [7]uint8{} && e.years == nil
+}
+
+// nextYear 返回不早于 year 的第一个允许的年份。
+func (e *specExtension) nextYear(year int) (int, bool) {
+    for _, y := range e.years {
+        if y >= year {
+            return y, true
+        }
+    }
+    return 0, false
+}
+
+// domMatches 如果指定时间满足日字段的扩展表达式，则返回 true。
+func (e *specExtension) domMatches(t time.Time) bool {
+    var (
+        day  = t.Day()
+        last = daysIn(t.Year(), t.Month())
+    )
+    if 1<<uint(last-day)&e.lastDom > 0 {
+        return true
+    }
+    if e.lastWeekday && day == nearestWeekday(t.Year(), t.Month(), last) {
+        return true
+    }
+    if e.nearestWeekday != 0 {
+        for n := dom.min; n <= uint(last); n++ {
+            if 1<<n&e.nearestWeekday > 0 && day == nearestWeekday(t.Year(), t.Month(), int(n)) {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// dowMatches 如果指定时间满足周字段的扩展表达式，则返回 true。
+func (e *specExtension) dowMatches(t time.Time) bool {
+    var (
+        day     = t.Day()
+        weekday = t.Weekday()
+    )
+    if 1<<uint(weekday)&e.lastDow > 0 && day+7 > daysIn(t.Year(), t.Month()) {
+        return true
+    }
+    return 1<<uint((day-1)/7+1)&e.nthDow[weekday] > 0
+}
+
+// daysIn 返回指定月份的天数。
+func daysIn(year int, month time.Month) int {
+    return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday 返回距当月第 day 天最近的工作日，不跨越月份边界。
+func nearestWeekday(year int, month time.Month, day int) int {
+    last := daysIn(year, month)
+    switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+    case time.Saturday:
+        if day == 1 {
+            return day + 2
+        }
+        return day - 1
+    case time.Sunday:
+        if day == last {
+            return day - 2
+        }
+        return day + 1
+    }
+    return day
+}
@@ -166,6 +166,47 @@ func TestNext(t *testing.T) {
     }
 }
 
+func TestNextExtended(t *testing.T) {
+    quartzParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional | Last | NearestWeekday | NthWeekday)
+    runs := []struct {
+        time, spec string
+        expected   string
+    }{
+        {"Mon Jul 9 23:35 2012", "0 0 0 L * ?", "Tue Jul 31 00:00 2012"},
+        {"Mon Jul 9 23:35 2012", "0 0 0 L 2 ?", "Thu Feb 28 00:00 2013"},
+        {"Mon Jul 9 23:35 2012", "0 0 0 L-2 * ?", "Sun Jul 29 00:00 2012"},
+        {"Sat Sep 1 00:00 2012", "0 0 0 LW * ?", "Fri Sep 28 00:00 2012"},
+        {"Sat Sep 1 00:00 2012", "0 0 0 15W * ?", "Fri Sep 14 00:00 2012"},
+        {"Fri Aug 31 00:00 2012", "0 0 0 1W * ?", "Mon Sep 3 00:00 2012"},
+        {"Mon Jul 9 23:35 2012", "0 0 0 1,L * ?", "Tue Jul 31 00:00 2012"},
+
+        {"Mon Jul 9 23:35 2012", "0 0 10 ? * 5L", "Fri Jul 27 10:00 2012"},
+        {"Mon Jul 9 23:35 2012", "0 0 10 ? * L", "Sat Jul 28 10:00 2012"},
+        {"Mon Jul 9 23:35 2012", "0 0 10 ? * FRIL", "Fri Jul 27 10:00 2012"},
+        {"Mon Jul 9 09:35 2012", "0 0 10 ? * 2#2", "Tue Jul 10 10:00 2012"},
+        {"Tue Jul 10 10:00 2012", "0 0 10 ? * TUE#2", "Tue Aug 14 10:00 2012"},
+
+        {"Mon Jul 9 23:35 2012", "0 0 0 1 1 ? 2015", "Thu Jan 1 00:00 2015"},
+        {"Mon Jul 9 23:35 2012", "0 0 0 1 1 ? 2030", "Tue Jan 1 00:00 2030"},
+        {"Mon Jul 9 23:35 2012", "0 0 0 1 1 ? 2010", ""},
+        {"Mon Jul 9 23:35 2012", "0 0 0 29 2 ? 2013-2020/2", ""},
+        {"Mon Jul 9 23:35 2012", "0 0 0 L 2 ? 2013-2020/2", "Thu Feb 28 00:00 2013"},
+    }
+
+    for _, c := range runs {
+        sched, err := quartzParser.Parse(c.spec)
+        if err != nil {
+            t.Error(err)
+            continue
+        }
+        actual := sched.Next(getTime(c.time))
+        expected := getTime(c.expected)
+        if !actual.Equal(expected) {
+            t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+        }
+    }
+}
+
 func TestErrors(t *testing.T) {
     invalidSpecs := []string{
         "xyz",
@@ -181,6 +222,68 @@ func TestErrors(t *testing.T) {
     }
 }
 
+func TestNextDST(t *testing.T) {
+    runs := []struct {
+        policy     DSTPolicy
+        time, spec string
+        expected   string
+    }{
+        // 纽约：3 月 11 日 02:00 跳到 03:00，11 月 4 日 02:00 回到 01:00。
+        {DSTDefault, "2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 * * ?", "2012-03-12T02:30:00-0400"},
+        {DSTSkip, "2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 * * ?", "2012-03-12T02:30:00-0400"},
+        {DSTRunOnce, "2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 * * ?", "2012-03-11T03:00:00-0400"},
+        {DSTRunBoth, "2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 * * ?", "2012-03-11T03:00:00-0400"},
+        {DSTRunOnce, "2012-03-11T03:00:00-0400", "TZ=America/New_York 0 0,30 2,3 * * ?", "2012-03-11T03:30:00-0400"},
+
+        {DSTSkip, "2012-11-04T00:00:00-0400", "TZ=America/New_York 0 30 1 * * ?", "2012-11-04T01:30:00-0400"},
+        {DSTDefault, "2012-11-04T01:45:00-0400", "TZ=America/New_York 0 30 1 * * ?", "2012-11-04T01:30:00-0500"},
+        {DSTSkip, "2012-11-04T01:45:00-0400", "TZ=America/New_York 0 30 1 * * ?", "2012-11-05T01:30:00-0500"},
+        {DSTRunOnce, "2012-11-04T01:45:00-0400", "TZ=America/New_York 0 30 1 * * ?", "2012-11-05T01:30:00-0500"},
+        {DSTRunBoth, "2012-11-04T01:45:00-0400", "TZ=America/New_York 0 30 1 * * ?", "2012-11-04T01:30:00-0500"},
+        {DSTRunBoth, "2012-11-04T01:30:00-0500", "TZ=America/New_York 0 30 1 * * ?", "2012-11-05T01:30:00-0500"},
+
+        // 每小时运行的计划按绝对时间运行，不受策略影响。
+        {DSTSkip, "2012-11-04T01:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0500"},
+        {DSTSkip, "2012-03-11T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T03:00:00-0400"},
+
+        // 悉尼（南半球）：10 月 6 日 02:00 跳到 03:00，4 月 7 日 03:00 回到 02:00。
+        {DSTSkip, "2024-10-06T00:00:00+1000", "TZ=Australia/Sydney 0 30 2 * * ?", "2024-10-07T02:30:00+1100"},
+        {DSTRunOnce, "2024-10-06T00:00:00+1000", "TZ=Australia/Sydney 0 30 2 * * ?", "2024-10-06T03:00:00+1100"},
+        {DSTDefault, "2024-04-07T02:45:00+1100", "TZ=Australia/Sydney 0 30 2 * * ?", "2024-04-07T02:30:00+1000"},
+        {DSTSkip, "2024-04-07T02:45:00+1100", "TZ=Australia/Sydney 0 30 2 * * ?", "2024-04-08T02:30:00+1000"},
+        {DSTRunBoth, "2024-04-07T02:45:00+1100", "TZ=Australia/Sydney 0 30 2 * * ?", "2024-04-07T02:30:00+1000"},
+
+        // 阿德莱德（半小时时区）：切换时间同悉尼。
+        {DSTSkip, "2024-10-06T00:00:00+0930", "TZ=Australia/Adelaide 0 30 2 * * ?", "2024-10-07T02:30:00+1030"},
+        {DSTRunOnce, "2024-10-06T00:00:00+0930", "TZ=Australia/Adelaide 0 30 2 * * ?", "2024-10-06T03:00:00+1030"},
+        {DSTRunBoth, "2024-04-07T02:45:00+1030", "TZ=Australia/Adelaide 0 30 2 * * ?", "2024-04-07T02:30:00+0930"},
+
+        // 豪勋爵岛（夏令时只调整半小时）：10 月 6 日 02:00 跳到 02:30，4 月 7 日 02:00 回到 01:30。
+        {DSTSkip, "2024-10-06T00:00:00+1030", "TZ=Australia/Lord_Howe 0 15 2 * * ?", "2024-10-07T02:15:00+1100"},
+        {DSTRunOnce, "2024-10-06T00:00:00+1030", "TZ=Australia/Lord_Howe 0 15 2 * * ?", "2024-10-06T02:30:00+1100"},
+        {DSTRunOnce, "2024-10-06T00:00:00+1030", "TZ=Australia/Lord_Howe 0 45 2 * * ?", "2024-10-06T02:45:00+1100"},
+        {DSTSkip, "2024-04-07T01:50:00+1100", "TZ=Australia/Lord_Howe 0 45 1 * * ?", "2024-04-08T01:45:00+1030"},
+        {DSTRunBoth, "2024-04-07T01:50:00+1100", "TZ=Australia/Lord_Howe 0 45 1 * * ?", "2024-04-07T01:45:00+1030"},
+
+        // 伦敦：3 月 31 日 01:00 跳到 02:00，10 月 27 日 02:00 回到 01:00。
+        {DSTRunOnce, "2024-03-31T00:00:00+0000", "TZ=Europe/London 0 30 1 * * ?", "2024-03-31T02:00:00+0100"},
+        {DSTRunOnce, "2024-10-27T01:40:00+0100", "TZ=Europe/London 0 30 1 * * ?", "2024-10-28T01:30:00+0000"},
+    }
+
+    for _, c := range runs {
+        sched, err := secondParser.WithDSTPolicy(c.policy).Parse(c.spec)
+        if err != nil {
+            t.Error(err)
+            continue
+        }
+        actual := sched.Next(getTime(c.time))
+        expected := getTime(c.expected)
+        if !actual.Equal(expected) {
+            t.Errorf("%s %s, \"%s\": (expected) %v != %v (actual)", c.policy, c.time, c.spec, expected, actual)
+        }
+    }
+}
+
 func getTime(value string) time.Time {
     if value == "" {
         return time.Time{}
@@ -0,0 +1,265 @@
+package gcron
+
+import (
+    "encoding/json"
+    "errors"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/camry/g/v2/glog"
+)
+
+// maxMisfireRuns 单个条目启动时最多补偿的运行次数。
+const maxMisfireRuns = 100
+
+// MisfirePolicy 描述启动时如何处理停机期间错过的运行。
+type MisfirePolicy int
+
+const (
+    MisfireSkip     MisfirePolicy = iota // 跳过错过的运行，等待下一次调度（默认）。
+    MisfireFireOnce                      // 无论错过多少次，只补偿运行一次。
+    MisfireFireAll                       // 补偿运行每一次错过的调度，最多 maxMisfireRuns 次。
+)
+
+// StoredEntry 持久化的条目状态。
+type StoredEntry struct {
+    Name string    `json:"name"`
+    Spec string    `json:"spec"`
+    Prev time.Time `json:"prev"`
+}
+
+// JobStore 持久化条目状态的存储接口。
+// Cron 运行期间在单独的 goroutine 中调用 Save，并合并同一条目尚未保存的状态，缓慢的存储不会阻塞调度。
+type JobStore interface {
+    // Load 返回所有已持久化的条目状态。
+    Load() ([]StoredEntry, error)
+    // Save 保存条目状态，同名条目将被覆盖。
+    Save(entry StoredEntry) error
+}
+
+var _ JobStore = (*FileJobStore)(nil)
+
+// FileJobStore 基于 JSON 文件的任务存储。
+type FileJobStore struct {
+    path    string
+    mu      sync.Mutex
+    entries map[string]StoredEntry
+}
+
+// NewFileJobStore 新建一个以 path 为存储文件的任务存储，文件不存在时将在首次保存时创建。
+func NewFileJobStore(path string) *FileJobStore {
+    return &FileJobStore{path: path}
+}
+
+// Load 返回所有已持久化的条目状态。
+func (s *FileJobStore) Load() ([]StoredEntry, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if err := s.load(); err != nil {
+        return nil, err
+    }
+    entries := make([]StoredEntry, 0, len(s.entries))
+    for _, e := range s.entries {
+        entries = append(entries, e)
+    }
+    sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+    return entries, nil
+}
+
+// Save 保存条目状态，同名条目将被覆盖。
+// 写入先落到临时文件再重命名，避免进程中途退出时损坏存储文件。
+func (s *FileJobStore) Save(entry StoredEntry) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if err := s.load(); err != nil {
+        return err
+    }
+    s.entries[entry.Name] = entry
+
+    data, err := json.MarshalIndent(s.entries, "", "  ")
+    if err != nil {
+        return err
+    }
+    tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+    if _, err = tmp.Write(data); err != nil {
+        _ = tmp.Close()
+        return err
+    }
+    if err = tmp.Close(); err != nil {
+        return err
+    }
+    return os.Rename(tmp.Name(), s.path)
+}
+
+// load 首次访问时从文件读取条目状态。
+func (s *FileJobStore) load() error {
+    if s.entries != nil {
+        return nil
+    }
+    entries := make(map[string]StoredEntry)
+    data, err := os.ReadFile(s.path)
+    if err != nil && !errors.Is(err, fs.ErrNotExist) {
+        return err
+    }
+    if len(data) > 0 {
+        if err = json.Unmarshal(data, &entries); err != nil {
+            return err
+        }
+    }
+    s.entries = entries
+    return nil
+}
+
+// restoreEntries 从任务存储加载具名条目的上次运行时间，恢复到已有的条目，并按错过策略补偿运行。
+func (c *Cron) restoreEntries(now time.Time) {
+    if c.store == nil {
+        return
+    }
+    c.stored = make(map[string]time.Time)
+    stored, err := c.store.Load()
+    if err != nil {
+        c.logger.Errorf(`gcron load job store: %v`, err)
+        return
+    }
+    for _, s := range stored {
+        c.stored[s.Name] = s.Prev
+    }
+    for _, e := range c.entries.items {
+        c.restoreEntry(e, now)
+    }
+}
+
+// restoreEntry 恢复具名条目的上次运行时间，并按错过策略补偿运行，调度程序运行时新增的条目同样经过恢复。
+func (c *Cron) restoreEntry(e *Entry, now time.Time) {
+    prev, ok := c.stored[e.Name]
+    if e.Name == "" || !ok || prev.IsZero() || e.Paused {
+        return
+    }
+    e.Prev = prev
+    missed := misfires(e.Schedule, prev, now)
+    if len(missed) == 0 || c.misfire == MisfireSkip {
+        return
+    }
+    if c.misfire == MisfireFireOnce {
+        missed = missed[len(missed)-1:]
+    }
+    for _, t := range missed {
+        c.startJob(e, t)
+        e.Prev = t
+    }
+    c.saveEntry(e)
+}
+
+// misfires 返回 (prev, now] 区间内错过的调度时间，最多 maxMisfireRuns 个。
+func misfires(schedule Schedule, prev, now time.Time) []time.Time {
+    var missed []time.Time
+    for t := schedule.Next(prev); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+        missed = append(missed, t)
+        if len(missed) == maxMisfireRuns {
+            break
+        }
+    }
+    return missed
+}
+
+// saveEntry 将具名条目的状态写入任务存储，调度程序运行时交给后台 goroutine 保存。
+func (c *Cron) saveEntry(e *Entry) {
+    if c.store == nil || e.Name == "" {
+        return
+    }
+    entry := StoredEntry{Name: e.Name, Spec: e.Spec, Prev: e.Prev}
+    if c.saver != nil {
+        // 调度程序运行时同步更新已持久化的状态，使移除后重新添加的同名条目从最新状态恢复。
+        c.stored[e.Name] = e.Prev
+        c.saver.save(entry)
+        return
+    }
+    if err := c.store.Save(entry); err != nil {
+        c.logger.Errorf(`gcron save entry %s: %v`, e.Name, err)
+    }
+}
+
+// entrySaver 在后台 goroutine 中保存条目状态，同一条目尚未保存的状态只保存最新的。
+type entrySaver struct {
+    store  JobStore
+    logger *glog.Helper
+
+    mu      sync.Mutex
+    pending map[string]StoredEntry
+
+    ready    chan struct{}
+    done     chan struct{}
+    finished chan struct{}
+}
+
+// startEntrySaver 新建并启动一个保存到 store 的 entrySaver。
+func startEntrySaver(store JobStore, logger *glog.Helper) *entrySaver {
+    s := &entrySaver{
+        store:    store,
+        logger:   logger,
+        pending:  make(map[string]StoredEntry),
+        ready:    make(chan struct{}, 1),
+        done:     make(chan struct{}),
+        finished: make(chan struct{}),
+    }
+    go s.run()
+    return s
+}
+
+// save 将条目状态加入待保存队列，从不阻塞。
+func (s *entrySaver) save(entry StoredEntry) {
+    s.mu.Lock()
+    s.pending[entry.Name] = entry
+    s.mu.Unlock()
+    select {
+    case s.ready <- struct{}{}:
+    default:
+    }
+}
+
+// stop 保存剩余的条目状态后停止。
+func (s *entrySaver) stop() {
+    close(s.done)
+    <-s.finished
+}
+
+// run 保存待保存的条目状态，直到 stop。
+func (s *entrySaver) run() {
+    defer close(s.finished)
+    for {
+        select {
+        case <-s.ready:
+            s.flush()
+        case <-s.done:
+            s.flush()
+            return
+        }
+    }
+}
+
+// flush 按名称顺序保存所有待保存的条目状态。
+func (s *entrySaver) flush() {
+    s.mu.Lock()
+    pending := s.pending
+    s.pending = make(map[string]StoredEntry)
+    s.mu.Unlock()
+
+    names := make([]string, 0, len(pending))
+    for name := range pending {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        if err := s.store.Save(pending[name]); err != nil {
+            s.logger.Errorf(`gcron save entry %s: %v`, name, err)
+        }
+    }
+}
@@ -0,0 +1,149 @@
+package gcron
+
+import (
+    "context"
+    "path/filepath"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestFileJobStore(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "jobs.json")
+    store := NewFileJobStore(path)
+
+    entries, err := store.Load()
+    if err != nil || len(entries) != 0 {
+        t.Fatalf("expected empty store, got %v %v", entries, err)
+    }
+
+    prev := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+    if err = store.Save(StoredEntry{Name: "b", Spec: "@daily", Prev: prev}); err != nil {
+        t.Fatal(err)
+    }
+    if err = store.Save(StoredEntry{Name: "a", Spec: "@hourly"}); err != nil {
+        t.Fatal(err)
+    }
+    if err = store.Save(StoredEntry{Name: "b", Spec: "@weekly", Prev: prev}); err != nil {
+        t.Fatal(err)
+    }
+
+    entries, err = NewFileJobStore(path).Load()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(entries) != 2 || entries[0].Name != "a" || entries[1].Name != "b" {
+        t.Fatalf("unexpected entries: %v", entries)
+    }
+    if entries[1].Spec != "@weekly" || !entries[1].Prev.Equal(prev) {
+        t.Errorf("unexpected entry: %v", entries[1])
+    }
+}
+
+func TestMisfirePolicy(t *testing.T) {
+    now := time.Now()
+    prev := time.Date(now.Year()-2, time.January, 1, 0, 0, 0, 0, time.Local)
+
+    tests := []struct {
+        policy   MisfirePolicy
+        expected int64
+    }{
+        {MisfireSkip, 0},
+        {MisfireFireOnce, 1},
+        {MisfireFireAll, 2},
+    }
+
+    for _, test := range tests {
+        path := filepath.Join(t.TempDir(), "jobs.json")
+        store := NewFileJobStore(path)
+        if err := store.Save(StoredEntry{Name: "yearly", Prev: prev}); err != nil {
+            t.Fatal(err)
+        }
+
+        var calls int64
+        cron := New(WithJobStore(store), WithMisfirePolicy(test.policy))
+        id, _ := cron.AddFunc("0 0 1 1 *", func() { atomic.AddInt64(&calls, 1) }, WithEntryName("yearly"))
+        cron.Start()
+        time.Sleep(50 * time.Millisecond)
+        cron.Stop(context.Background())
+
+        if c := atomic.LoadInt64(&calls); c != test.expected {
+            t.Errorf("policy %d: expected %d runs, got %d", test.policy, test.expected, c)
+        }
+        entry := cron.Entry(id)
+        if entry.Spec != "0 0 1 1 *" || entry.Prev.Before(prev) {
+            t.Errorf("policy %d: unexpected entry %+v", test.policy, entry)
+        }
+
+        stored, _ := NewFileJobStore(path).Load()
+        if test.expected > 0 && !stored[0].Prev.Equal(time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local)) {
+            t.Errorf("policy %d: expected last run persisted, got %v", test.policy, stored[0].Prev)
+        }
+    }
+}
+
+func TestJobStoreAddWhileRunning(t *testing.T) {
+    now := time.Now()
+    prev := time.Date(now.Year()-2, time.January, 1, 0, 0, 0, 0, time.Local)
+    last := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local)
+    path := filepath.Join(t.TempDir(), "jobs.json")
+    store := NewFileJobStore(path)
+    if err := store.Save(StoredEntry{Name: "yearly", Prev: prev}); err != nil {
+        t.Fatal(err)
+    }
+
+    cron := New(WithJobStore(store), WithMisfirePolicy(MisfireFireOnce))
+    cron.Start()
+    ran := make(chan struct{}, 2)
+    id, _ := cron.AddFunc("0 0 1 1 *", func() { ran <- struct{}{} }, WithEntryName("yearly"))
+    expectSignal(t, ran, "expected missed run for entry added while running")
+    expectNoSignal(t, ran, "expected a single missed run")
+    if entry := cron.Entry(id); !entry.Prev.Equal(last) {
+        t.Errorf("expected prev %v, got %v", last, entry.Prev)
+    }
+    cron.Stop(context.Background())
+
+    stored, _ := NewFileJobStore(path).Load()
+    if len(stored) != 1 || !stored[0].Prev.Equal(last) {
+        t.Errorf("expected last run persisted, got %v", stored)
+    }
+}
+
+// blockingStore 在 release 关闭前阻塞每次保存的任务存储。
+type blockingStore struct {
+    release chan struct{}
+    mu      sync.Mutex
+    saved   map[string]StoredEntry
+}
+
+func (s *blockingStore) Load() ([]StoredEntry, error) { return nil, nil }
+
+func (s *blockingStore) Save(entry StoredEntry) error {
+    <-s.release
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.saved[entry.Name] = entry
+    return nil
+}
+
+func TestSlowJobStore(t *testing.T) {
+    store := &blockingStore{release: make(chan struct{}), saved: map[string]StoredEntry{}}
+    ran := make(chan struct{}, 3)
+    cron, fire := fireEvery(FuncJob(func() { ran <- struct{}{} }), []Option{WithJobStore(store)}, WithEntryName("poll"))
+
+    // 存储阻塞时调度照常进行。
+    fire()
+    expectSignal(t, ran, "expected first run")
+    fire()
+    expectSignal(t, ran, "expected second run while store is blocked")
+    prev := cron.Entry(1).Prev
+
+    close(store.release)
+    cron.Stop(context.Background())
+    store.mu.Lock()
+    defer store.mu.Unlock()
+    if saved := store.saved["poll"]; !saved.Prev.Equal(prev) {
+        t.Errorf("expected latest state saved on stop, got %v, want %v", saved.Prev, prev)
+    }
+}
@@ -0,0 +1,271 @@
+package glog
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+var _ Logger = (*AsyncLogger)(nil)
+
+// ErrAsyncClosed 异步日志记录器已关闭。
+var ErrAsyncClosed = errors.New("glog: async logger closed")
+
+// AsyncPolicy 缓冲区满时的处理策略。
+type AsyncPolicy int
+
+const (
+    AsyncBlock      AsyncPolicy = iota // 阻塞调用方直到缓冲区有空位。
+    AsyncDropNewest                    // 丢弃新写入的日志。
+    AsyncDropOldest                    // 丢弃缓冲区中最早的日志。
+)
+
+// AsyncOption 异步日志记录器选项。
+type AsyncOption func(*AsyncLogger)
+
+// AsyncBufferSize 配置缓冲区可容纳的日志条数，默认 1024。
+func AsyncBufferSize(size int) AsyncOption {
+    return func(l *AsyncLogger) {
+        if size > 0 {
+            l.size = size
+        }
+    }
+}
+
+// AsyncOverflow 配置缓冲区满时的处理策略，默认 AsyncBlock。
+func AsyncOverflow(policy AsyncPolicy) AsyncOption {
+    return func(l *AsyncLogger) {
+        l.policy = policy
+    }
+}
+
+// AsyncFlushInterval 配置刷新底层日志记录器的间隔，默认 1 秒，小于等于 0 时不定期刷新。
+func AsyncFlushInterval(d time.Duration) AsyncOption {
+    return func(l *AsyncLogger) {
+        l.interval = d
+    }
+}
+
+// asyncRecord 缓冲区中的一条日志。
+type asyncRecord struct {
+    level   Level
+    keyvals []any
+}
+
+// AsyncLogger 异步日志记录器，日志先写入有界环形缓冲区，再由后台协程写入底层日志记录器。
+//
+// 底层日志记录器实现了 Sync() error 时，后台协程会定期及在 Sync 时调用它。
+type AsyncLogger struct {
+    logger   Logger
+    size     int
+    policy   AsyncPolicy
+    interval time.Duration
+    dropped  atomic.Uint64
+
+    mu        sync.Mutex
+    notFull   *sync.Cond
+    drained   *sync.Cond
+    buf       []asyncRecord
+    head      int
+    n         int
+    enqueued  uint64 // 写入缓冲区的日志数。
+    processed uint64 // 已写入底层或被丢弃的日志数。
+    err       error  // 上次 Sync 以来底层返回的第一个错误。
+    closed    bool
+
+    wake chan struct{}
+    done chan struct{}
+}
+
+// NewAsyncLogger 新建一个包装 logger 的异步日志记录器，使用后需调用 Close 将剩余日志写入 logger。
+//
+// 例子
+//
+//	async := glog.NewAsyncLogger(glog.NewStdLogger(w), glog.AsyncOverflow(glog.AsyncDropOldest))
+//	defer async.Close(context.Background())
+func NewAsyncLogger(logger Logger, opts ...AsyncOption) *AsyncLogger {
+    l := &AsyncLogger{
+        logger:   logger,
+        size:     1024,
+        interval: time.Second,
+        wake:     make(chan struct{}, 1),
+        done:     make(chan struct{}),
+    }
+    for _, o := range opts {
+        o(l)
+    }
+    l.buf = make([]asyncRecord, l.size)
+    l.notFull = sync.NewCond(&l.mu)
+    l.drained = sync.NewCond(&l.mu)
+    go l.run()
+    return l
+}
+
+// Log 将日志写入缓冲区，缓冲区满时按策略阻塞或丢弃。
+func (l *AsyncLogger) Log(level Level, keyvals ...any) error {
+    // 复制键值对，调用方可能在返回后复用切片。
+    r := asyncRecord{level: level, keyvals: append([]any(nil), keyvals...)}
+
+    l.mu.Lock()
+    for !l.closed && l.n == len(l.buf) {
+        switch l.policy {
+        case AsyncDropNewest:
+            l.mu.Unlock()
+            l.dropped.Add(1)
+            return nil
+        case AsyncDropOldest:
+            l.buf[l.head] = asyncRecord{}
+            l.head = (l.head + 1) % len(l.buf)
+            l.n--
+            l.processed++
+            l.dropped.Add(1)
+        default:
+            l.notFull.Wait()
+        }
+    }
+    if l.closed {
+        l.mu.Unlock()
+        return ErrAsyncClosed
+    }
+    l.buf[(l.head+l.n)%len(l.buf)] = r
+    l.n++
+    l.enqueued++
+    l.mu.Unlock()
+
+    select {
+    case l.wake <- struct{}{}:
+    default:
+    }
+    return nil
+}
+
+// Dropped 返回因缓冲区满被丢弃的日志数。
+func (l *AsyncLogger) Dropped() uint64 {
+    return l.dropped.Load()
+}
+
+// Sync 等待调用前写入的日志全部写入底层日志记录器并刷新底层，返回此前底层返回的第一个错误。
+func (l *AsyncLogger) Sync() error {
+    l.mu.Lock()
+    target := l.enqueued
+    for l.processed < target {
+        l.drained.Wait()
+    }
+    err := l.err
+    l.err = nil
+    l.mu.Unlock()
+    return errors.Join(err, l.syncLogger())
+}
+
+// Close 停止接收日志，并等待缓冲区中的日志全部写入底层日志记录器，ctx 结束时放弃等待并返回 ctx 的错误。
+// Close 不关闭底层日志记录器。
+func (l *AsyncLogger) Close(ctx context.Context) error {
+    l.mu.Lock()
+    if !l.closed {
+        l.closed = true
+        l.notFull.Broadcast()
+    }
+    l.mu.Unlock()
+    select {
+    case l.wake <- struct{}{}:
+    default:
+    }
+
+    select {
+    case <-l.done:
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+    l.mu.Lock()
+    err := l.err
+    l.err = nil
+    l.mu.Unlock()
+    return errors.Join(err, l.syncLogger())
+}
+
+// run 在后台将缓冲区中的日志写入底层日志记录器，并定期刷新底层。
+func (l *AsyncLogger) run() {
+    defer close(l.done)
+    var tick <-chan time.Time
+    if l.interval > 0 {
+        ticker := time.NewTicker(l.interval)
+        defer ticker.Stop()
+        tick = ticker.C
+    }
+    var (
+        batch []asyncRecord
+        dirty bool
+    )
+    for {
+        var closed bool
+        batch, closed = l.take(batch[:0])
+        if len(batch) > 0 {
+            l.write(batch)
+            dirty = true
+            continue
+        }
+        if closed {
+            return
+        }
+        select {
+        case <-l.wake:
+        case <-tick:
+            if dirty {
+                dirty = false
+                if err := l.syncLogger(); err != nil {
+                    l.setErr(err)
+                }
+            }
+        }
+    }
+}
+
+// take 取出缓冲区中的全部日志追加到 batch，并返回是否已关闭。
+func (l *AsyncLogger) take(batch []asyncRecord) ([]asyncRecord, bool) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    for ; l.n > 0; l.n-- {
+        batch = append(batch, l.buf[l.head])
+        l.buf[l.head] = asyncRecord{}
+        l.head = (l.head + 1) % len(l.buf)
+    }
+    l.notFull.Broadcast()
+    return batch, l.closed
+}
+
+// write 将 batch 写入底层日志记录器，并唤醒等待的 Sync。
+func (l *AsyncLogger) write(batch []asyncRecord) {
+    var first error
+    for i := range batch {
+        if err := l.logger.Log(batch[i].level, batch[i].keyvals...); err != nil && first == nil {
+            first = err
+        }
+        batch[i] = asyncRecord{}
+    }
+    l.mu.Lock()
+    l.processed += uint64(len(batch))
+    if l.err == nil {
+        l.err = first
+    }
+    l.drained.Broadcast()
+    l.mu.Unlock()
+}
+
+// setErr 记录底层返回的第一个错误。
+func (l *AsyncLogger) setErr(err error) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if l.err == nil {
+        l.err = err
+    }
+}
+
+// syncLogger 底层日志记录器实现了 Sync() error 时调用它。
+func (l *AsyncLogger) syncLogger() error {
+    if s, ok := l.logger.(interface{ Sync() error }); ok {
+        return s.Sync()
+    }
+    return nil
+}
@@ -0,0 +1,188 @@
+package glog
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "io"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+)
+
+// gateLogger 在 gate 关闭前阻塞每次写入的日志记录器。
+type gateLogger struct {
+    gate    chan struct{}
+    started chan struct{}
+    once    sync.Once
+
+    mu    sync.Mutex
+    msgs  []string
+    syncs int
+}
+
+func newGateLogger() *gateLogger {
+    return &gateLogger{gate: make(chan struct{}), started: make(chan struct{})}
+}
+
+func (l *gateLogger) Log(_ Level, keyvals ...any) error {
+    l.once.Do(func() { close(l.started) })
+    <-l.gate
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.msgs = append(l.msgs, keyvals[1].(string))
+    return nil
+}
+
+func (l *gateLogger) Sync() error {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.syncs++
+    return nil
+}
+
+func (l *gateLogger) messages() string {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    return strings.Join(l.msgs, ",")
+}
+
+// fillAsync 写入 m0 并等待后台协程阻塞在底层，然后写入 msgs。
+func fillAsync(t *testing.T, a *AsyncLogger, inner *gateLogger, msgs ...string) {
+    t.Helper()
+    _ = a.Log(LevelInfo, "msg", "m0")
+    <-inner.started
+    for _, m := range msgs {
+        if err := a.Log(LevelInfo, "msg", m); err != nil {
+            t.Fatal(err)
+        }
+    }
+}
+
+func TestAsyncLogger(t *testing.T) {
+    var buf bytes.Buffer
+    a := NewAsyncLogger(NewStdLogger(&buf))
+    h := NewHelper(a)
+    h.Info("hello")
+    h.Infow("key", "value")
+    if err := a.Sync(); err != nil {
+        t.Fatal(err)
+    }
+    if got, want := buf.String(), "INFO msg=hello\nINFO key=value\n"; got != want {
+        t.Errorf("expected %q, got %q", want, got)
+    }
+    if err := a.Close(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+    if err := a.Log(LevelInfo, "msg", "late"); !errors.Is(err, ErrAsyncClosed) {
+        t.Errorf("expected ErrAsyncClosed, got %v", err)
+    }
+}
+
+func TestAsyncLoggerOverflow(t *testing.T) {
+    tests := []struct {
+        name    string
+        policy  AsyncPolicy
+        want    string
+        dropped uint64
+    }{
+        {"drop newest", AsyncDropNewest, "m0,m1,m2", 2},
+        {"drop oldest", AsyncDropOldest, "m0,m3,m4", 2},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            inner := newGateLogger()
+            a := NewAsyncLogger(inner, AsyncBufferSize(2), AsyncOverflow(test.policy))
+            fillAsync(t, a, inner, "m1", "m2", "m3", "m4")
+            close(inner.gate)
+            if err := a.Close(context.Background()); err != nil {
+                t.Fatal(err)
+            }
+            if got := inner.messages(); got != test.want {
+                t.Errorf("expected %s, got %s", test.want, got)
+            }
+            if got := a.Dropped(); got != test.dropped {
+                t.Errorf("expected %d dropped, got %d", test.dropped, got)
+            }
+        })
+    }
+}
+
+func TestAsyncLoggerBlock(t *testing.T) {
+    inner := newGateLogger()
+    a := NewAsyncLogger(inner, AsyncBufferSize(1))
+    fillAsync(t, a, inner, "m1")
+
+    logged := make(chan struct{})
+    go func() {
+        _ = a.Log(LevelInfo, "msg", "m2")
+        close(logged)
+    }()
+    select {
+    case <-logged:
+        t.Fatal("expected Log to block while buffer is full")
+    case <-time.After(50 * time.Millisecond):
+    }
+    close(inner.gate)
+    <-logged
+    if err := a.Close(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+    if got := inner.messages(); got != "m0,m1,m2" {
+        t.Errorf("expected m0,m1,m2, got %s", got)
+    }
+    if a.Dropped() != 0 {
+        t.Errorf("expected nothing dropped, got %d", a.Dropped())
+    }
+}
+
+func TestAsyncLoggerClose(t *testing.T) {
+    inner := newGateLogger()
+    a := NewAsyncLogger(inner)
+    fillAsync(t, a, inner, "m1")
+
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+    if err := a.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+        t.Errorf("expected deadline exceeded, got %v", err)
+    }
+    close(inner.gate)
+    if err := a.Close(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+    if got := inner.messages(); got != "m0,m1" {
+        t.Errorf("expected buffer drained on close, got %s", got)
+    }
+}
+
+func TestAsyncLoggerFlushInterval(t *testing.T) {
+    inner := newGateLogger()
+    close(inner.gate)
+    a := NewAsyncLogger(inner, AsyncFlushInterval(10*time.Millisecond))
+    defer a.Close(context.Background())
+    _ = a.Log(LevelInfo, "msg", "m0")
+
+    deadline := time.Now().Add(time.Second)
+    for {
+        inner.mu.Lock()
+        syncs := inner.syncs
+        inner.mu.Unlock()
+        if syncs > 0 {
+            break
+        }
+        if time.Now().After(deadline) {
+            t.Fatal("expected periodic flush to sync the inner logger")
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+}
+
+func BenchmarkAsyncLogger(b *testing.B) {
+    a := NewAsyncLogger(NewStdLogger(io.Discard), AsyncOverflow(AsyncDropNewest))
+    defer a.Close(context.Background())
+    log := NewHelper(a)
+    for i := 0; i < b.N; i++ {
+        log.Info("test")
+    }
+}
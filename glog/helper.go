@@ -61,12 +61,9 @@ func (h *Helper) WithContext(ctx context.Context) *Helper {
     }
 }
 
-// Enabled 如果给定级别高于此级别，则返回 true。它委托给底层 *Filter。
+// Enabled 如果给定级别高于此级别，则返回 true。它委托给底层 *Filter，底层为 *MultiLogger 时任一日志记录器启用即返回 true。
 func (h *Helper) Enabled(level Level) bool {
-    if l, ok := h.logger.(*Filter); ok {
-        return level >= l.level
-    }
-    return true
+    return enabled(h.logger, level)
 }
 
 // Log 按级别和键值打印日志。
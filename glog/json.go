@@ -0,0 +1,260 @@
+package glog
+
+import (
+    "bytes"
+    "encoding/base64"
+    "encoding/json"
+    "fmt"
+    "io"
+    "math"
+    "strconv"
+    "sync"
+    "time"
+    "unicode/utf8"
+)
+
+var _ Logger = (*jsonLogger)(nil)
+
+// JSONOption JSON 日志记录器选项。
+type JSONOption func(*jsonLogger)
+
+// JSONLevelKey 配置日志级别的键，默认 LevelKey。
+func JSONLevelKey(key string) JSONOption {
+    return func(l *jsonLogger) {
+        l.levelKey = key
+    }
+}
+
+// JSONTimeKey 配置日志时间的键，默认 "time"，为空时不输出时间。
+func JSONTimeKey(key string) JSONOption {
+    return func(l *jsonLogger) {
+        l.timeKey = key
+    }
+}
+
+// JSONMessageKey 配置输出消息的键，默认 DefaultMessageKey。
+// 键值对中以 DefaultMessageKey 为键的值被视为消息，紧跟在级别和时间之后输出。
+func JSONMessageKey(key string) JSONOption {
+    return func(l *jsonLogger) {
+        l.msgKey = key
+    }
+}
+
+// JSONTimeFormat 配置日志时间及 time.Time 类型值的格式，默认 time.RFC3339Nano。
+func JSONTimeFormat(layout string) JSONOption {
+    return func(l *jsonLogger) {
+        l.timeFormat = layout
+    }
+}
+
+type jsonLogger struct {
+    w          io.Writer
+    isDiscard  bool
+    mu         sync.Mutex
+    pool       *sync.Pool
+    levelKey   string
+    timeKey    string
+    msgKey     string
+    timeFormat string
+    now        func() time.Time
+}
+
+// NewJSONLogger 新建一个每行输出一个 JSON 对象的日志记录器。
+//
+// 键和值按 JSON 规则转义，值按类型转换：
+// error 输出 Error()，time.Time 按时间格式输出，[]byte 为有效 UTF-8 时输出为字符串、否则输出 base64，
+// fmt.Stringer 输出 String()，json.Marshaler 及其它类型按 encoding/json 编码，编码失败时输出 %+v 格式的字符串。
+func NewJSONLogger(w io.Writer, opts ...JSONOption) Logger {
+    l := &jsonLogger{
+        w:          w,
+        isDiscard:  w == io.Discard,
+        levelKey:   LevelKey,
+        timeKey:    "time",
+        msgKey:     DefaultMessageKey,
+        timeFormat: time.RFC3339Nano,
+        now:        time.Now,
+        pool: &sync.Pool{
+            New: func() any {
+                return new(bytes.Buffer)
+            },
+        },
+    }
+    for _, o := range opts {
+        o(l)
+    }
+    return l
+}
+
+// Log 打印键值对日志。
+func (l *jsonLogger) Log(level Level, keyvals ...any) error {
+    if l.isDiscard || len(keyvals) == 0 {
+        return nil
+    }
+    if (len(keyvals) & 1) == 1 {
+        keyvals = append(keyvals, "KEYVALS UNPAIRED")
+    }
+
+    buf := l.pool.Get().(*bytes.Buffer)
+    defer l.pool.Put(buf)
+    defer buf.Reset()
+
+    b := buf.AvailableBuffer()
+    b = append(b, '{')
+    b = l.appendField(b, l.levelKey, level.String())
+    if l.timeKey != "" {
+        b = l.appendField(b, l.timeKey, l.now())
+    }
+    msg := -1
+    for i := 0; i < len(keyvals); i += 2 {
+        if key, ok := keyvals[i].(string); ok && key == DefaultMessageKey {
+            msg = i
+            b = l.appendField(b, l.msgKey, keyvals[i+1])
+            break
+        }
+    }
+    for i := 0; i < len(keyvals); i += 2 {
+        if i != msg {
+            b = l.appendField(b, keyvals[i], keyvals[i+1])
+        }
+    }
+    b = append(b, '}', '\n')
+    buf.Write(b)
+
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    _, err := l.w.Write(buf.Bytes())
+    return err
+}
+
+func (l *jsonLogger) Close() error {
+    return nil
+}
+
+// appendField 追加一个键值对，非首个键值对前加逗号。
+func (l *jsonLogger) appendField(b []byte, key, value any) []byte {
+    if b[len(b)-1] != '{' {
+        b = append(b, ',')
+    }
+    k, ok := key.(string)
+    if !ok {
+        k = fmt.Sprint(key)
+    }
+    b = appendJSONString(b, k)
+    b = append(b, ':')
+    return l.appendValue(b, value)
+}
+
+// appendValue 按类型追加 JSON 值。
+func (l *jsonLogger) appendValue(b []byte, value any) []byte {
+    switch v := value.(type) {
+    case nil:
+        return append(b, "null"...)
+    case string:
+        return appendJSONString(b, v)
+    case bool:
+        return strconv.AppendBool(b, v)
+    case int:
+        return strconv.AppendInt(b, int64(v), 10)
+    case int8:
+        return strconv.AppendInt(b, int64(v), 10)
+    case int16:
+        return strconv.AppendInt(b, int64(v), 10)
+    case int32:
+        return strconv.AppendInt(b, int64(v), 10)
+    case int64:
+        return strconv.AppendInt(b, v, 10)
+    case uint:
+        return strconv.AppendUint(b, uint64(v), 10)
+    case uint8:
+        return strconv.AppendUint(b, uint64(v), 10)
+    case uint16:
+        return strconv.AppendUint(b, uint64(v), 10)
+    case uint32:
+        return strconv.AppendUint(b, uint64(v), 10)
+    case uint64:
+        return strconv.AppendUint(b, v, 10)
+    case float32:
+        return appendJSONFloat(b, float64(v), 32)
+    case float64:
+        return appendJSONFloat(b, v, 64)
+    case time.Time:
+        return appendJSONString(b, v.Format(l.timeFormat))
+    case []byte:
+        if utf8.Valid(v) {
+            return appendJSONString(b, string(v))
+        }
+        return appendJSONString(b, base64.StdEncoding.EncodeToString(v))
+    case error, fmt.Stringer:
+        // fmt 会处理 nil 接收者和方法中的 panic。
+        return appendJSONString(b, fmt.Sprint(v))
+    }
+    data, err := json.Marshal(value)
+    if err != nil {
+        return appendJSONString(b, fmt.Sprintf("%+v", value))
+    }
+    return append(b, data...)
+}
+
+// appendJSONFloat 追加浮点数，NaN 和正负无穷输出为字符串。
+func appendJSONFloat(b []byte, f float64, bits int) []byte {
+    switch {
+    case math.IsNaN(f):
+        return append(b, `"NaN"`...)
+    case math.IsInf(f, 1):
+        return append(b, `"+Inf"`...)
+    case math.IsInf(f, -1):
+        return append(b, `"-Inf"`...)
+    }
+    return strconv.AppendFloat(b, f, 'g', -1, bits)
+}
+
+// appendJSONString 追加转义后的 JSON 字符串，无效的 UTF-8 字节替换为 U+FFFD。
+func appendJSONString(b []byte, s string) []byte {
+    const hex = "0123456789abcdef"
+    b = append(b, '"')
+    start := 0
+    for i := 0; i < len(s); {
+        c := s[i]
+        if c < utf8.RuneSelf {
+            if c >= 0x20 && c != '"' && c != '\\' {
+                i++
+                continue
+            }
+            b = append(b, s[start:i]...)
+            switch c {
+            case '"', '\\':
+                b = append(b, '\\', c)
+            case '\n':
+                b = append(b, '\\', 'n')
+            case '\r':
+                b = append(b, '\\', 'r')
+            case '\t':
+                b = append(b, '\\', 't')
+            default:
+                b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+            }
+            i++
+            start = i
+            continue
+        }
+        r, size := utf8.DecodeRuneInString(s[i:])
+        if r == utf8.RuneError && size == 1 {
+            b = append(b, s[start:i]...)
+            b = append(b, `\ufffd`...)
+            i += size
+            start = i
+            continue
+        }
+        // U+2028、U+2029 在 JavaScript 中是换行符，转义以便日志被安全地嵌入。
+        if r == '\u2028' || r == '\u2029' {
+            b = append(b, s[start:i]...)
+            b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xf])
+            i += size
+            start = i
+            continue
+        }
+        i += size
+    }
+    b = append(b, s[start:]...)
+    return append(b, '"')
+}
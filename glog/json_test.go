@@ -0,0 +1,121 @@
+package glog
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "io"
+    "math"
+    "strings"
+    "testing"
+    "time"
+)
+
+type jsonStringer struct{}
+
+func (jsonStringer) String() string { return "stringer" }
+
+func TestJSONLogger(t *testing.T) {
+    var b bytes.Buffer
+    now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+    logger := NewJSONLogger(&b, JSONLevelKey("severity"), JSONTimeKey("ts"), JSONMessageKey("message"), JSONTimeFormat(time.RFC3339))
+    logger.(*jsonLogger).now = func() time.Time { return now }
+
+    _ = logger.Log(LevelWarn, "k", "v", DefaultMessageKey, "hello", "n", 1)
+    if s, expected := b.String(), `{"severity":"WARN","ts":"2024-01-02T03:04:05Z","message":"hello","k":"v","n":1}`+"\n"; s != expected {
+        t.Fatalf("expected %q, got %q", expected, s)
+    }
+}
+
+func TestJSONLoggerValues(t *testing.T) {
+    var b bytes.Buffer
+    now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+    logger := NewJSONLogger(&b, JSONTimeKey(""))
+    var nilErr *json.SyntaxError
+
+    _ = logger.Log(LevelInfo,
+        "quote\"key\n", "line1\nline2\t\"q\" \\ \x01  ",
+        "err", errors.New("boom"),
+        "nilErr", error(nilErr),
+        "time", now,
+        "bytes", []byte("text"),
+        "binary", []byte{0xff, 0x00},
+        "stringer", jsonStringer{},
+        "duration", 1500*time.Millisecond,
+        "nan", math.NaN(),
+        "float", 1.5,
+        "bool", true,
+        "nil", nil,
+        "map", map[string]int{"a": 1},
+        "chan", make(chan int),
+        "invalid", "a\xffb",
+        1, "int key",
+        "unpaired",
+    )
+
+    line := b.String()
+    if strings.Count(line, "\n") != 1 || !strings.HasSuffix(line, "\n") {
+        t.Fatalf("expected one line, got %q", line)
+    }
+    var m map[string]any
+    if err := json.Unmarshal([]byte(line), &m); err != nil {
+        t.Fatalf("invalid JSON %q: %v", line, err)
+    }
+    expected := map[string]any{
+        "level":       "INFO",
+        "quote\"key\n": "line1\nline2\t\"q\" \\ \x01  ",
+        "err":         "boom",
+        "nilErr":      "<nil>",
+        "time":        "2024-01-02T03:04:05Z",
+        "bytes":       "text",
+        "binary":      "/wA=",
+        "stringer":    "stringer",
+        "duration":    "1.5s",
+        "nan":         "NaN",
+        "float":       1.5,
+        "bool":        true,
+        "nil":         nil,
+        "map":         map[string]any{"a": float64(1)},
+        "invalid":     "a\ufffdb",
+        "1":           "int key",
+        "unpaired":    "KEYVALS UNPAIRED",
+    }
+    for k, v := range expected {
+        if actual, ok := m[k]; !ok || !jsonEqual(actual, v) {
+            t.Errorf("%q: expected %#v, got %#v", k, v, actual)
+        }
+    }
+    if s, ok := m["chan"].(string); !ok || !strings.HasPrefix(s, "0x") {
+        t.Errorf("expected unmarshalable value formatted as string, got %#v", m["chan"])
+    }
+}
+
+func jsonEqual(a, b any) bool {
+    x, _ := json.Marshal(a)
+    y, _ := json.Marshal(b)
+    return bytes.Equal(x, y)
+}
+
+func TestJSONLoggerDefaults(t *testing.T) {
+    var b bytes.Buffer
+    logger := NewHelper(NewJSONLogger(&b))
+    logger.Infof("hello %s", "world")
+
+    var m map[string]any
+    if err := json.Unmarshal(b.Bytes(), &m); err != nil {
+        t.Fatal(err)
+    }
+    if m[LevelKey] != "INFO" || m[DefaultMessageKey] != "hello world" {
+        t.Errorf("unexpected entry %v", m)
+    }
+    if _, err := time.Parse(time.RFC3339Nano, m["time"].(string)); err != nil {
+        t.Errorf("expected RFC3339 time, got %v", m["time"])
+    }
+}
+
+func BenchmarkJSONLogger(b *testing.B) {
+    log := NewHelper(NewJSONLogger(io.Discard))
+    for i := 0; i < b.N; i++ {
+        log.Infow("key", "value", "err", errors.New("error"))
+    }
+}
@@ -61,5 +61,11 @@ func WithContext(ctx context.Context, l Logger) Logger {
         fv := *v
         fv.logger = WithContext(ctx, fv.logger)
         return &fv
+    case *MultiLogger:
+        loggers := make([]Logger, len(v.loggers))
+        for i, l := range v.loggers {
+            loggers[i] = WithContext(ctx, l)
+        }
+        return &MultiLogger{loggers: loggers}
     }
 }
@@ -0,0 +1,218 @@
+package glog
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "strconv"
+    "sync"
+    "time"
+    "unicode"
+    "unicode/utf8"
+)
+
+var _ Logger = (*logfmtLogger)(nil)
+
+type logfmtLogger struct {
+    w         io.Writer
+    isDiscard bool
+    mu        sync.Mutex
+    pool      *sync.Pool
+}
+
+// NewLogfmtLogger 新建一个输出 logfmt 格式的日志记录器，每行以 level=级别 开头，可由 ParseLogfmt 解析。
+func NewLogfmtLogger(w io.Writer) Logger {
+    return &logfmtLogger{
+        w:         w,
+        isDiscard: w == io.Discard,
+        pool: &sync.Pool{
+            New: func() any {
+                return new(bytes.Buffer)
+            },
+        },
+    }
+}
+
+// Log 打印键值对日志。
+func (l *logfmtLogger) Log(level Level, keyvals ...any) error {
+    if l.isDiscard || len(keyvals) == 0 {
+        return nil
+    }
+
+    buf := l.pool.Get().(*bytes.Buffer)
+    defer l.pool.Put(buf)
+    defer buf.Reset()
+
+    b := AppendLogfmt(buf.AvailableBuffer(), LevelKey, level.String())
+    b = append(b, ' ')
+    b = AppendLogfmt(b, keyvals...)
+    b = append(b, '\n')
+    buf.Write(b)
+
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    _, err := l.w.Write(buf.Bytes())
+    return err
+}
+
+func (l *logfmtLogger) Close() error {
+    return nil
+}
+
+// AppendLogfmt 将键值对按 logfmt 格式追加到 dst，键值对之间以空格分隔。
+//
+// 键中的空白、=、" 和控制字符替换为 _；值为空或包含空白、=、"、控制字符及无效 UTF-8 时加引号并转义。
+// 浮点数使用最短的精确表示，time.Time 使用 time.RFC3339Nano，nil 输出为 null。
+func AppendLogfmt(dst []byte, keyvals ...any) []byte {
+    if (len(keyvals) & 1) == 1 {
+        keyvals = append(keyvals, "KEYVALS UNPAIRED")
+    }
+    for i := 0; i < len(keyvals); i += 2 {
+        if i > 0 {
+            dst = append(dst, ' ')
+        }
+        dst = appendLogfmtKey(dst, keyvals[i])
+        dst = append(dst, '=')
+        dst = appendLogfmtValue(dst, keyvals[i+1])
+    }
+    return dst
+}
+
+// appendLogfmtKey 追加键，非法字符替换为 _。
+func appendLogfmtKey(dst []byte, key any) []byte {
+    k, ok := key.(string)
+    if !ok {
+        k = fmt.Sprint(key)
+    }
+    if k == "" {
+        return append(dst, '_')
+    }
+    for _, r := range k {
+        if r == '=' || r == '"' || r == utf8.RuneError || unicode.IsSpace(r) || unicode.IsControl(r) {
+            r = '_'
+        }
+        dst = utf8.AppendRune(dst, r)
+    }
+    return dst
+}
+
+// appendLogfmtValue 按类型格式化并追加值。
+func appendLogfmtValue(dst []byte, value any) []byte {
+    var s string
+    switch v := value.(type) {
+    case nil:
+        return append(dst, "null"...)
+    case string:
+        s = v
+    case []byte:
+        s = string(v)
+    case bool:
+        return strconv.AppendBool(dst, v)
+    case int:
+        return strconv.AppendInt(dst, int64(v), 10)
+    case int64:
+        return strconv.AppendInt(dst, v, 10)
+    case uint64:
+        return strconv.AppendUint(dst, v, 10)
+    case float32:
+        return strconv.AppendFloat(dst, float64(v), 'g', -1, 32)
+    case float64:
+        return strconv.AppendFloat(dst, v, 'g', -1, 64)
+    case time.Time:
+        s = v.Format(time.RFC3339Nano)
+    default:
+        // fmt 会调用 error 和 fmt.Stringer 的方法，并处理 nil 接收者和方法中的 panic。
+        s = fmt.Sprint(v)
+    }
+    if !needsQuote(s) {
+        return append(dst, s...)
+    }
+    return strconv.AppendQuote(dst, s)
+}
+
+// needsQuote 如果值必须加引号才能被无歧义地解析，则返回 true。
+func needsQuote(s string) bool {
+    if s == "" {
+        return true
+    }
+    for _, r := range s {
+        if r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+            return true
+        }
+    }
+    return false
+}
+
+// ParseLogfmt 解析一行 logfmt 格式的日志，返回交替排列的键和值。
+// 没有 = 的键的值为空字符串，例如 NewStdLogger 输出的日志级别。
+func ParseLogfmt(line string) ([]string, error) {
+    var keyvals []string
+    for i := 0; ; {
+        for i < len(line) && isLogfmtSpace(line[i]) {
+            i++
+        }
+        if i >= len(line) {
+            return keyvals, nil
+        }
+
+        start := i
+        for i < len(line) && !isLogfmtSpace(line[i]) && line[i] != '=' {
+            if line[i] == '"' {
+                return nil, fmt.Errorf("logfmt: unexpected quote in key at offset %d", i)
+            }
+            i++
+        }
+        if i == start {
+            return nil, fmt.Errorf("logfmt: missing key at offset %d", i)
+        }
+        key := line[start:i]
+        if i >= len(line) || line[i] != '=' {
+            keyvals = append(keyvals, key, "")
+            continue
+        }
+        i++
+
+        if i < len(line) && line[i] == '"' {
+            end, err := quotedEnd(line, i)
+            if err != nil {
+                return nil, err
+            }
+            value, err := strconv.Unquote(line[i:end])
+            if err != nil {
+                return nil, fmt.Errorf("logfmt: invalid quoted value at offset %d: %v", i, err)
+            }
+            keyvals = append(keyvals, key, value)
+            i = end
+            if i < len(line) && !isLogfmtSpace(line[i]) {
+                return nil, fmt.Errorf("logfmt: unexpected %q after quoted value at offset %d", line[i], i)
+            }
+            continue
+        }
+        start = i
+        for i < len(line) && !isLogfmtSpace(line[i]) {
+            if line[i] == '"' || line[i] == '=' {
+                return nil, fmt.Errorf("logfmt: unexpected %q in value at offset %d", line[i], i)
+            }
+            i++
+        }
+        keyvals = append(keyvals, key, line[start:i])
+    }
+}
+
+// quotedEnd 返回从 start 处引号开始的带引号值的结束位置（不含）。
+func quotedEnd(line string, start int) (int, error) {
+    for i := start + 1; i < len(line); i++ {
+        switch line[i] {
+        case '\\':
+            i++
+        case '"':
+            return i + 1, nil
+        }
+    }
+    return 0, fmt.Errorf("logfmt: unterminated quoted value at offset %d", start)
+}
+
+// isLogfmtSpace 如果 c 是分隔键值对的空白字符，则返回 true。
+func isLogfmtSpace(c byte) bool {
+    return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
@@ -0,0 +1,103 @@
+package glog
+
+import (
+    "bytes"
+    "errors"
+    "io"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestAppendLogfmt(t *testing.T) {
+    var nilErr *errorString
+    tests := []struct {
+        keyvals  []any
+        expected string
+    }{
+        {[]any{"msg", "hi"}, `msg=hi`},
+        {[]any{"msg", "user said hi"}, `msg="user said hi"`},
+        {[]any{"msg", ""}, `msg=""`},
+        {[]any{"msg", "a=b"}, `msg="a=b"`},
+        {[]any{"msg", `say "hi"`}, `msg="say \"hi\""`},
+        {[]any{"err", errors.New("line1\nline2")}, `err="line1\nline2"`},
+        {[]any{"ctl", "a\x00b"}, `ctl="a\x00b"`},
+        {[]any{"utf8", "你好"}, `utf8=你好`},
+        {[]any{"bad", "a\xffb"}, `bad="a\xffb"`},
+        {[]any{"bytes", []byte("b c")}, `bytes="b c"`},
+        {[]any{"f", 0.30000000000000004, "g", float32(1.5), "big", 1e21}, `f=0.30000000000000004 g=1.5 big=1e+21`},
+        {[]any{"t", time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)}, `t=2024-01-02T03:04:05.0000006Z`},
+        {[]any{"d", 1500 * time.Millisecond, "b", true, "n", -3, "nil", nil}, `d=1.5s b=true n=-3 nil=null`},
+        {[]any{"nilErr", error(nilErr)}, `nilErr=<nil>`},
+        {[]any{"a key", 1, "", 2, 3, 4}, `a_key=1 _=2 3=4`},
+        {[]any{"unpaired"}, `unpaired="KEYVALS UNPAIRED"`},
+    }
+    for _, test := range tests {
+        if actual := string(AppendLogfmt(nil, test.keyvals...)); actual != test.expected {
+            t.Errorf("%v: expected %s, got %s", test.keyvals, test.expected, actual)
+        }
+    }
+}
+
+type errorString struct{ s string }
+
+func (e *errorString) Error() string { return e.s }
+
+func TestParseLogfmt(t *testing.T) {
+    tests := []struct {
+        line     string
+        expected []string
+    }{
+        {`level=INFO msg="user said hi" k=v`, []string{"level", "INFO", "msg", "user said hi", "k", "v"}},
+        {`INFO msg=a k=v`, []string{"INFO", "", "msg", "a", "k", "v"}},
+        {`  a=  b="" c="x\ny\t\"z\"" `, []string{"a", "", "b", "", "c", "x\ny\t\"z\""}},
+        {"", nil},
+    }
+    for _, test := range tests {
+        actual, err := ParseLogfmt(test.line)
+        if err != nil {
+            t.Errorf("%q: %v", test.line, err)
+            continue
+        }
+        if !reflect.DeepEqual(actual, test.expected) {
+            t.Errorf("%q: expected %q, got %q", test.line, test.expected, actual)
+        }
+    }
+
+    for _, line := range []string{`k="unterminated`, `=v`, `k="a"b`, `k=a"b`, `k"=v`, `k=a=b`, `k="\q"`} {
+        if _, err := ParseLogfmt(line); err == nil {
+            t.Errorf("%q: expected error", line)
+        }
+    }
+}
+
+func TestLogfmtLoggerRoundTrip(t *testing.T) {
+    var b bytes.Buffer
+    logger := NewLogfmtLogger(&b)
+    values := []string{"plain", "with space", "", `quote " and \ backslash`, "new\nline\r\ttab", "a=b", "\x01\x7f", "你好 世界", "a\xffb"}
+    for _, v := range values {
+        b.Reset()
+        if err := logger.Log(LevelWarn, "msg", v); err != nil {
+            t.Fatal(err)
+        }
+        line := b.String()
+        if bytes.Count(b.Bytes(), []byte("\n")) != 1 {
+            t.Errorf("%q: expected a single line, got %q", v, line)
+        }
+        keyvals, err := ParseLogfmt(line)
+        if err != nil {
+            t.Errorf("%q: %v", line, err)
+            continue
+        }
+        if expected := []string{LevelKey, "WARN", "msg", v}; !reflect.DeepEqual(keyvals, expected) {
+            t.Errorf("expected %q, got %q", expected, keyvals)
+        }
+    }
+}
+
+func BenchmarkLogfmtLogger(b *testing.B) {
+    log := NewHelper(NewLogfmtLogger(io.Discard))
+    for i := 0; i < b.N; i++ {
+        log.Infow("key", "value with space", "err", errors.New("error"))
+    }
+}
@@ -0,0 +1,86 @@
+package glog
+
+import (
+    "context"
+    "errors"
+
+    "github.com/camry/g/v2/gerrors/gerror"
+)
+
+var _ Logger = (*MultiLogger)(nil)
+
+// MultiLogger 将日志分发到多个日志记录器的日志记录器。
+type MultiLogger struct {
+    loggers []Logger
+}
+
+// Tee 新建一个将日志依次分发到 loggers 的日志记录器，每个日志记录器可以用 NewFilter 包装以单独过滤。
+//
+// 例子
+//
+//	logger := glog.Tee(
+//	    glog.NewStdLogger(os.Stdout),
+//	    glog.NewFilter(glog.NewJSONLogger(errFile), glog.FilterLevel(glog.LevelError)),
+//	)
+func Tee(loggers ...Logger) *MultiLogger {
+    return &MultiLogger{loggers: append([]Logger(nil), loggers...)}
+}
+
+// Log 将日志分发到每个日志记录器，即使部分失败也会写入其余的日志记录器，并汇总返回所有错误。
+func (m *MultiLogger) Log(level Level, keyvals ...any) error {
+    var errs []error
+    for i, l := range m.loggers {
+        kvs := keyvals
+        // Filter 会就地替换需要脱敏的值，每个日志记录器使用各自的副本以免相互影响。
+        if i < len(m.loggers)-1 {
+            kvs = append([]any(nil), keyvals...)
+        }
+        if err := l.Log(level, kvs...); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return m.join(errs)
+}
+
+// Close 关闭所有实现了 Close() error 或 Close(context.Context) error 的日志记录器，并汇总返回所有错误。
+// 后者（如 AsyncLogger）以 context.Background() 关闭，会等待缓冲的日志全部写入。
+func (m *MultiLogger) Close() error {
+    var errs []error
+    for _, l := range m.loggers {
+        var err error
+        switch c := l.(type) {
+        case interface{ Close() error }:
+            err = c.Close()
+        case interface{ Close(context.Context) error }:
+            err = c.Close(context.Background())
+        }
+        if err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return m.join(errs)
+}
+
+// join 将多个日志记录器返回的错误汇总为一个错误。
+func (m *MultiLogger) join(errs []error) error {
+    if len(errs) == 0 {
+        return nil
+    }
+    return gerror.Wrapf(errors.Join(errs...), "glog: %d of %d loggers failed", len(errs), len(m.loggers))
+}
+
+// enabled 如果给定级别的日志会被 l 记录，则返回 true。
+func enabled(l Logger, level Level) bool {
+    switch v := l.(type) {
+    case *Filter:
+        return level >= v.level
+    case *MultiLogger:
+        for _, sink := range v.loggers {
+            if enabled(sink, level) {
+                return true
+            }
+        }
+        return false
+    }
+    return true
+}
@@ -0,0 +1,110 @@
+package glog
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "io"
+    "strings"
+    "testing"
+
+    "github.com/camry/g/v2/gerrors/gerror"
+)
+
+// errLogger 每次写入都返回 err 的日志记录器。
+type errLogger struct {
+    err error
+}
+
+func (l errLogger) Log(Level, ...any) error { return l.err }
+
+func TestTee(t *testing.T) {
+    var all, errs bytes.Buffer
+    log := NewHelper(Tee(
+        NewStdLogger(&all),
+        NewFilter(NewStdLogger(&errs), FilterLevel(LevelError), FilterKey("password")),
+    ))
+    log.Info("started")
+    log.Errorw("msg", "login failed", "password", "123456")
+
+    if got, want := all.String(), "INFO msg=started\nERROR msg=login failed password=123456\n"; got != want {
+        t.Errorf("expected %q, got %q", want, got)
+    }
+    if got, want := errs.String(), "ERROR msg=login failed password=***\n"; got != want {
+        t.Errorf("expected %q, got %q", want, got)
+    }
+}
+
+func TestTeeErrors(t *testing.T) {
+    var buf bytes.Buffer
+    err1, err2 := errors.New("disk full"), errors.New("broken pipe")
+    logger := Tee(errLogger{err1}, NewStdLogger(&buf), errLogger{err2})
+
+    err := logger.Log(LevelInfo, "msg", "hello")
+    if err == nil {
+        t.Fatal("expected error")
+    }
+    if !errors.Is(err, err1) || !errors.Is(err, err2) {
+        t.Errorf("expected both sink errors, got %v", err)
+    }
+    if !gerror.HasStack(err) || !strings.HasPrefix(err.Error(), "glog: 2 of 3 loggers failed") {
+        t.Errorf("unexpected error %v", err)
+    }
+    if buf.String() != "INFO msg=hello\n" {
+        t.Errorf("expected healthy sink to be written, got %q", buf.String())
+    }
+    if err = Tee(NewStdLogger(io.Discard)).Log(LevelInfo, "msg", "hello"); err != nil {
+        t.Errorf("expected no error, got %v", err)
+    }
+}
+
+func TestTeeEnabled(t *testing.T) {
+    tests := []struct {
+        name   string
+        logger Logger
+        level  Level
+        want   bool
+    }{
+        {"empty", Tee(), LevelError, false},
+        {"all filtered", Tee(NewFilter(DefaultLogger, FilterLevel(LevelWarn)), NewFilter(DefaultLogger, FilterLevel(LevelError))), LevelInfo, false},
+        {"one enabled", Tee(NewFilter(DefaultLogger, FilterLevel(LevelWarn)), NewFilter(DefaultLogger, FilterLevel(LevelError))), LevelWarn, true},
+        {"unfiltered", Tee(NewFilter(DefaultLogger, FilterLevel(LevelError)), DefaultLogger), LevelDebug, true},
+        {"nested", Tee(Tee(NewFilter(DefaultLogger, FilterLevel(LevelError)))), LevelInfo, false},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            if got := NewHelper(test.logger).Enabled(test.level); got != test.want {
+                t.Errorf("expected %v, got %v", test.want, got)
+            }
+            if got := NewHelper(test.logger).WithContext(context.Background()).Enabled(test.level); got != test.want {
+                t.Errorf("expected %v with context, got %v", test.want, got)
+            }
+        })
+    }
+}
+
+func TestTeeClose(t *testing.T) {
+    var buf bytes.Buffer
+    inner := newGateLogger()
+    async := NewAsyncLogger(inner)
+    logger := Tee(NewStdLogger(&buf), async)
+    fillAsync(t, async, inner, "m1")
+    close(inner.gate)
+
+    if err := logger.Close(); err != nil {
+        t.Fatal(err)
+    }
+    if got := inner.messages(); got != "m0,m1" {
+        t.Errorf("expected async sink drained on close, got %s", got)
+    }
+    if err := async.Log(LevelInfo, "msg", "late"); !errors.Is(err, ErrAsyncClosed) {
+        t.Errorf("expected async sink closed, got %v", err)
+    }
+}
+
+func BenchmarkTee(b *testing.B) {
+    log := NewHelper(Tee(NewStdLogger(io.Discard), NewFilter(NewStdLogger(io.Discard), FilterLevel(LevelError))))
+    for i := 0; i < b.N; i++ {
+        log.Info("test")
+    }
+}
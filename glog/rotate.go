@@ -0,0 +1,426 @@
+package glog
+
+import (
+    "compress/gzip"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "os/signal"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "syscall"
+    "time"
+)
+
+// backupTimeFormat 备份文件名中的时间格式。
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatePeriod 按时间轮转的周期。
+type RotatePeriod int
+
+const (
+    RotateNone   RotatePeriod = iota // 不按时间轮转。
+    RotateHourly                     // 每小时轮转。
+    RotateDaily                      // 每天轮转。
+)
+
+// next 返回 t 之后下一个轮转时间，不按时间轮转时返回零时间。
+func (p RotatePeriod) next(t time.Time) time.Time {
+    switch p {
+    case RotateHourly:
+        return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+    case RotateDaily:
+        return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+    default:
+        return time.Time{}
+    }
+}
+
+// RotateOption 轮转文件写入器选项。
+type RotateOption func(*RotateWriter)
+
+// RotateMaxSize 配置文件的最大字节数，写入后将超出时先轮转，小于等于 0 时不按大小轮转。
+func RotateMaxSize(size int64) RotateOption {
+    return func(w *RotateWriter) {
+        w.maxSize = size
+    }
+}
+
+// RotateEvery 配置按时间轮转的周期。
+func RotateEvery(period RotatePeriod) RotateOption {
+    return func(w *RotateWriter) {
+        w.period = period
+    }
+}
+
+// RotateCompress 配置使用 gzip 压缩轮转出的备份文件。
+func RotateCompress() RotateOption {
+    return func(w *RotateWriter) {
+        w.compress = true
+    }
+}
+
+// RotateMaxAge 配置备份文件的最长保留时间，按备份文件名中的时间计算，小于等于 0 时不限制。
+func RotateMaxAge(age time.Duration) RotateOption {
+    return func(w *RotateWriter) {
+        w.maxAge = age
+    }
+}
+
+// RotateMaxBackups 配置最多保留的备份文件数，小于等于 0 时不限制。
+func RotateMaxBackups(n int) RotateOption {
+    return func(w *RotateWriter) {
+        w.maxBackups = n
+    }
+}
+
+// RotateReopenOnSIGHUP 配置在收到 SIGHUP 信号时重新打开文件，以配合外部的 logrotate 等工具。
+func RotateReopenOnSIGHUP() RotateOption {
+    return func(w *RotateWriter) {
+        w.reopenOnSIGHUP = true
+    }
+}
+
+// RotateWriter 按大小或时间轮转的文件写入器，可作为任意日志记录器的输出。
+//
+// 轮转时当前文件被重命名为“文件名-时间.扩展名”形式的备份文件，并创建新文件继续写入；
+// 压缩和清理过期备份在后台进行，不阻塞写入。
+type RotateWriter struct {
+    filename       string
+    maxSize        int64
+    period         RotatePeriod
+    compress       bool
+    maxAge         time.Duration
+    maxBackups     int
+    reopenOnSIGHUP bool
+    now            func() time.Time
+    rename         func(oldpath, newpath string) error
+
+    mu         sync.Mutex
+    file       *os.File // 为 nil 时表示上次轮转或重新打开失败，下次写入时重试打开。
+    closed     bool
+    size       int64
+    nextRotate time.Time
+
+    mill    chan struct{}
+    signals chan os.Signal
+    done    chan struct{}
+    wg      sync.WaitGroup
+}
+
+var _ io.WriteCloser = (*RotateWriter)(nil)
+
+// NewRotateWriter 新建一个写入 filename 的轮转文件写入器，文件已存在时追加写入。
+//
+// 例子
+//
+//	w, err := glog.NewRotateWriter("/var/log/app.log", glog.RotateMaxSize(100<<20), glog.RotateEvery(glog.RotateDaily), glog.RotateCompress(), glog.RotateMaxBackups(7))
+//	logger := glog.NewStdLogger(w)
+func NewRotateWriter(filename string, opts ...RotateOption) (*RotateWriter, error) {
+    w := &RotateWriter{
+        filename: filename,
+        now:      time.Now,
+        rename:   os.Rename,
+        mill:     make(chan struct{}, 1),
+        done:     make(chan struct{}),
+    }
+    for _, o := range opts {
+        o(w)
+    }
+    if err := w.open(); err != nil {
+        return nil, err
+    }
+
+    w.wg.Add(1)
+    go w.runMill()
+    if w.reopenOnSIGHUP {
+        w.signals = make(chan os.Signal, 1)
+        signal.Notify(w.signals, syscall.SIGHUP)
+        w.wg.Add(1)
+        go w.runSignals()
+    }
+    return w, nil
+}
+
+// Write 写入日志，写入前按需轮转。轮转失败时输出到标准错误，并继续写入原文件。
+func (w *RotateWriter) Write(p []byte) (int, error) {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    if w.closed {
+        return 0, os.ErrClosed
+    }
+    if w.file == nil {
+        if err := w.open(); err != nil {
+            return 0, err
+        }
+    }
+    if w.shouldRotate(int64(len(p))) {
+        if err := w.rotate(); err != nil {
+            _, _ = fmt.Fprintf(os.Stderr, "glog: rotate %s: %v\n", w.filename, err)
+            if w.file == nil {
+                if err = w.open(); err != nil {
+                    return 0, err
+                }
+            }
+        }
+    }
+    n, err := w.file.Write(p)
+    w.size += int64(n)
+    return n, err
+}
+
+// Rotate 立即轮转文件。
+func (w *RotateWriter) Rotate() error {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    if w.closed {
+        return os.ErrClosed
+    }
+    return w.rotate()
+}
+
+// Reopen 关闭并重新打开文件，文件被外部移走时创建新文件。打开失败时下次写入会重试。
+func (w *RotateWriter) Reopen() error {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    if w.closed {
+        return os.ErrClosed
+    }
+    if err := w.closeFile(); err != nil {
+        return err
+    }
+    return w.open()
+}
+
+// Close 关闭文件，并等待后台的压缩和清理完成。
+func (w *RotateWriter) Close() error {
+    w.mu.Lock()
+    if w.closed {
+        w.mu.Unlock()
+        return nil
+    }
+    w.closed = true
+    err := w.closeFile()
+    w.mu.Unlock()
+
+    if w.signals != nil {
+        signal.Stop(w.signals)
+    }
+    close(w.done)
+    w.wg.Wait()
+    return err
+}
+
+// shouldRotate 如果写入 n 字节前需要轮转，则返回 true。
+func (w *RotateWriter) shouldRotate(n int64) bool {
+    if w.maxSize > 0 && w.size > 0 && w.size+n > w.maxSize {
+        return true
+    }
+    return !w.nextRotate.IsZero() && !w.now().Before(w.nextRotate)
+}
+
+// open 打开或创建文件，并根据文件的大小和修改时间恢复轮转状态。
+func (w *RotateWriter) open() error {
+    if err := os.MkdirAll(filepath.Dir(w.filename), 0o755); err != nil {
+        return err
+    }
+    f, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+    if err != nil {
+        return err
+    }
+    info, err := f.Stat()
+    if err != nil {
+        _ = f.Close()
+        return err
+    }
+    w.file, w.size = f, info.Size()
+    since := w.now()
+    if info.Size() > 0 && info.ModTime().Before(since) {
+        since = info.ModTime()
+    }
+    w.nextRotate = w.period.next(since)
+    return nil
+}
+
+// closeFile 关闭当前文件，无论成功与否都不再使用它。
+func (w *RotateWriter) closeFile() error {
+    if w.file == nil {
+        return nil
+    }
+    err := w.file.Close()
+    w.file = nil
+    return err
+}
+
+// rotate 将当前文件重命名为备份文件并打开新文件，然后通知后台压缩和清理。
+// 重命名失败时重新打开原文件，并推迟到下个周期再按时间轮转。
+func (w *RotateWriter) rotate() error {
+    if err := w.closeFile(); err != nil {
+        return err
+    }
+    if err := w.rename(w.filename, w.backupName(w.now())); err != nil && !errors.Is(err, os.ErrNotExist) {
+        if w.open() == nil {
+            w.nextRotate = w.period.next(w.now())
+        }
+        return err
+    }
+    if err := w.open(); err != nil {
+        return err
+    }
+    w.nextRotate = w.period.next(w.now())
+    select {
+    case w.mill <- struct{}{}:
+    default:
+    }
+    return nil
+}
+
+// backupName 返回在 t 时刻轮转出的备份文件名，时间使用本地时区，与已有备份重名时顺延 1 毫秒。
+func (w *RotateWriter) backupName(t time.Time) string {
+    dir, base := filepath.Split(w.filename)
+    ext := filepath.Ext(base)
+    prefix := strings.TrimSuffix(base, ext)
+    for t = t.Local(); ; t = t.Add(time.Millisecond) {
+        name := filepath.Join(dir, prefix+"-"+t.Format(backupTimeFormat)+ext)
+        if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
+            if _, err = os.Stat(name + ".gz"); errors.Is(err, os.ErrNotExist) {
+                return name
+            }
+        }
+    }
+}
+
+// runMill 在后台压缩备份文件并清理过期的备份文件。
+func (w *RotateWriter) runMill() {
+    defer w.wg.Done()
+    for {
+        select {
+        case <-w.mill:
+            w.millRunOrReport()
+        case <-w.done:
+            // 完成关闭前最后一次轮转的压缩和清理。
+            select {
+            case <-w.mill:
+                w.millRunOrReport()
+            default:
+            }
+            return
+        }
+    }
+}
+
+// millRunOrReport 运行一次压缩和清理，出错时输出到标准错误。
+func (w *RotateWriter) millRunOrReport() {
+    if err := w.millRun(); err != nil {
+        _, _ = fmt.Fprintf(os.Stderr, "glog: rotate %s: %v\n", w.filename, err)
+    }
+}
+
+// runSignals 收到 SIGHUP 时重新打开文件。
+func (w *RotateWriter) runSignals() {
+    defer w.wg.Done()
+    for {
+        select {
+        case <-w.signals:
+            if err := w.Reopen(); err != nil {
+                _, _ = fmt.Fprintf(os.Stderr, "glog: reopen %s: %v\n", w.filename, err)
+            }
+        case <-w.done:
+            return
+        }
+    }
+}
+
+// backupFile 一个备份文件。
+type backupFile struct {
+    path string
+    time time.Time
+}
+
+// millRun 压缩未压缩的备份文件，并删除超出数量或过期的备份文件。
+func (w *RotateWriter) millRun() error {
+    backups, err := w.backups()
+    if err != nil {
+        return err
+    }
+    var errs []error
+    var keep []backupFile
+    for i, b := range backups {
+        if (w.maxBackups > 0 && i >= w.maxBackups) || (w.maxAge > 0 && w.now().Sub(b.time) > w.maxAge) {
+            errs = append(errs, os.Remove(b.path))
+            continue
+        }
+        keep = append(keep, b)
+    }
+    if w.compress {
+        for _, b := range keep {
+            if !strings.HasSuffix(b.path, ".gz") {
+                errs = append(errs, compressFile(b.path))
+            }
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// backups 返回所有备份文件，按备份时间从新到旧排列。
+func (w *RotateWriter) backups() ([]backupFile, error) {
+    dir, base := filepath.Split(w.filename)
+    if dir == "" {
+        dir = "."
+    }
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, err
+    }
+    ext := filepath.Ext(base)
+    prefix := strings.TrimSuffix(base, ext) + "-"
+    var backups []backupFile
+    for _, e := range entries {
+        name := e.Name()
+        if e.IsDir() || !strings.HasPrefix(name, prefix) {
+            continue
+        }
+        ts := strings.TrimSuffix(strings.TrimSuffix(name[len(prefix):], ".gz"), ext)
+        t, err := time.ParseInLocation(backupTimeFormat, ts, time.Local)
+        if err != nil {
+            continue
+        }
+        backups = append(backups, backupFile{path: filepath.Join(dir, name), time: t})
+    }
+    sort.Slice(backups, func(i, j int) bool { return backups[i].time.After(backups[j].time) })
+    return backups, nil
+}
+
+// compressFile 将文件压缩为同名的 .gz 文件并删除原文件。
+func compressFile(path string) (err error) {
+    src, err := os.Open(path)
+    if err != nil {
+        return err
+    }
+    defer src.Close()
+    dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+    if err != nil {
+        return err
+    }
+    defer func() {
+        if err != nil {
+            _ = dst.Close()
+            _ = os.Remove(path + ".gz")
+        }
+    }()
+    gz := gzip.NewWriter(dst)
+    if _, err = io.Copy(gz, src); err != nil {
+        return err
+    }
+    if err = gz.Close(); err != nil {
+        return err
+    }
+    if err = dst.Close(); err != nil {
+        return err
+    }
+    _ = src.Close()
+    return os.Remove(path)
+}
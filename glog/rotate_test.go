@@ -0,0 +1,247 @@
+package glog
+
+import (
+    "compress/gzip"
+    "encoding/json"
+    "errors"
+    "io"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "syscall"
+    "testing"
+    "time"
+)
+
+// rotateFiles 返回目录中的文件名及其内容，.gz 文件返回解压后的内容。
+func rotateFiles(t *testing.T, dir string) map[string]string {
+    t.Helper()
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    files := map[string]string{}
+    for _, e := range entries {
+        f, err := os.Open(filepath.Join(dir, e.Name()))
+        if err != nil {
+            t.Fatal(err)
+        }
+        var r io.Reader = f
+        if strings.HasSuffix(e.Name(), ".gz") {
+            if r, err = gzip.NewReader(f); err != nil {
+                t.Fatal(err)
+            }
+        }
+        data, err := io.ReadAll(r)
+        f.Close()
+        if err != nil {
+            t.Fatal(err)
+        }
+        files[e.Name()] = string(data)
+    }
+    return files
+}
+
+// backupContents 返回除当前文件外所有文件的内容，按文件名排序。
+func backupContents(files map[string]string, current string) []string {
+    var names []string
+    for name := range files {
+        if name != current {
+            names = append(names, name)
+        }
+    }
+    sort.Strings(names)
+    contents := make([]string, len(names))
+    for i, name := range names {
+        contents[i] = files[name]
+    }
+    return contents
+}
+
+func TestRotateWriterSize(t *testing.T) {
+    dir := t.TempDir()
+    w, err := NewRotateWriter(filepath.Join(dir, "app.log"), RotateMaxSize(10))
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, line := range []string{"line1\n", "line2\n", "line3\n"} {
+        if _, err = w.Write([]byte(line)); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if err = w.Close(); err != nil {
+        t.Fatal(err)
+    }
+
+    files := rotateFiles(t, dir)
+    if files["app.log"] != "line3\n" {
+        t.Errorf("expected current file to hold last line, got %q", files["app.log"])
+    }
+    if backups := backupContents(files, "app.log"); strings.Join(backups, "") != "line1\nline2\n" {
+        t.Errorf("unexpected backups %q in %v", backups, files)
+    }
+    if _, err = w.Write([]byte("x")); err == nil {
+        t.Error("expected error writing to closed writer")
+    }
+}
+
+func TestRotateWriterRetention(t *testing.T) {
+    dir := t.TempDir()
+    old := filepath.Join(dir, "app-2000-01-01T00-00-00.000.log")
+    if err := os.WriteFile(old, []byte("old\n"), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    w, err := NewRotateWriter(filepath.Join(dir, "app.log"), RotateCompress(), RotateMaxBackups(2), RotateMaxAge(24*time.Hour))
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, line := range []string{"a\n", "b\n", "c\n", "d\n"} {
+        _, _ = w.Write([]byte(line))
+        if err = w.Rotate(); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if err = w.Close(); err != nil {
+        t.Fatal(err)
+    }
+
+    files := rotateFiles(t, dir)
+    if len(files) != 3 {
+        t.Fatalf("expected current file and 2 backups, got %v", files)
+    }
+    for name := range files {
+        if name != "app.log" && !strings.HasSuffix(name, ".log.gz") {
+            t.Errorf("expected compressed backup, got %s", name)
+        }
+    }
+    if backups := backupContents(files, "app.log"); strings.Join(backups, "") != "c\nd\n" {
+        t.Errorf("expected newest backups to be kept, got %q", backups)
+    }
+}
+
+func TestRotateWriterPeriod(t *testing.T) {
+    dir := t.TempDir()
+    var (
+        mu  sync.Mutex
+        now = time.Date(2024, 1, 1, 10, 30, 0, 0, time.Local)
+    )
+    w, err := NewRotateWriter(filepath.Join(dir, "app.log"), RotateEvery(RotateHourly))
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer w.Close()
+    w.mu.Lock()
+    w.now = func() time.Time {
+        mu.Lock()
+        defer mu.Unlock()
+        return now
+    }
+    w.nextRotate = RotateHourly.next(now)
+    w.mu.Unlock()
+
+    _, _ = w.Write([]byte("10:30\n"))
+    mu.Lock()
+    now = now.Add(20 * time.Minute)
+    mu.Unlock()
+    _, _ = w.Write([]byte("10:50\n"))
+    mu.Lock()
+    now = now.Add(20 * time.Minute)
+    mu.Unlock()
+    _, _ = w.Write([]byte("11:10\n"))
+
+    files := rotateFiles(t, dir)
+    if files["app.log"] != "11:10\n" {
+        t.Errorf("expected new hour in current file, got %q", files["app.log"])
+    }
+    if content := files["app-2024-01-01T11-10-00.000.log"]; content != "10:30\n10:50\n" {
+        t.Errorf("expected previous hour in backup, got %v", files)
+    }
+}
+
+func TestRotateWriterReopen(t *testing.T) {
+    dir := t.TempDir()
+    path := filepath.Join(dir, "app.log")
+    w, err := NewRotateWriter(path, RotateReopenOnSIGHUP())
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer w.Close()
+    logger := NewJSONLogger(w, JSONTimeKey(""))
+
+    _ = logger.Log(LevelInfo, "msg", "before")
+    if err = os.Rename(path, path+".1"); err != nil {
+        t.Fatal(err)
+    }
+    _ = logger.Log(LevelInfo, "msg", "moved")
+    w.signals <- syscall.SIGHUP
+    deadline := time.Now().Add(time.Second)
+    for {
+        if _, err = os.Stat(path); err == nil || time.Now().After(deadline) {
+            break
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+    _ = logger.Log(LevelInfo, "msg", "after")
+
+    files := rotateFiles(t, dir)
+    if lines := strings.Count(files["app.log.1"], "\n"); lines != 2 {
+        t.Errorf("expected 2 lines in moved file, got %q", files["app.log.1"])
+    }
+    var m map[string]any
+    if err = json.Unmarshal([]byte(files["app.log"]), &m); err != nil || m["msg"] != "after" {
+        t.Errorf("expected reopened file to hold new line, got %q", files["app.log"])
+    }
+}
+
+func TestRotateWriterErrors(t *testing.T) {
+    dir := t.TempDir()
+    path := filepath.Join(dir, "app.log")
+    w, err := NewRotateWriter(path, RotateMaxSize(10))
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer w.Close()
+
+    // 重命名失败时继续写入原文件。
+    w.mu.Lock()
+    w.rename = func(string, string) error { return errors.New("rename failed") }
+    w.mu.Unlock()
+    for _, line := range []string{"line1\n", "line2\n"} {
+        if _, err = w.Write([]byte(line)); err != nil {
+            t.Fatalf("expected write to survive failed rotation, got %v", err)
+        }
+    }
+    w.mu.Lock()
+    w.rename = os.Rename
+    w.mu.Unlock()
+    _, _ = w.Write([]byte("line3\n"))
+    files := rotateFiles(t, dir)
+    if files["app.log"] != "line3\n" || strings.Join(backupContents(files, "app.log"), "") != "line1\nline2\n" {
+        t.Errorf("expected rotation to resume after rename recovered, got %v", files)
+    }
+
+    // 重新打开失败时，下次写入重试打开。
+    if err = os.Remove(path); err != nil {
+        t.Fatal(err)
+    }
+    if err = os.Mkdir(path, 0o755); err != nil {
+        t.Fatal(err)
+    }
+    if err = w.Reopen(); err == nil {
+        t.Fatal("expected reopen to fail while path is a directory")
+    }
+    if _, err = w.Write([]byte("lost\n")); err == nil {
+        t.Error("expected write to fail while path is a directory")
+    }
+    if err = os.Remove(path); err != nil {
+        t.Fatal(err)
+    }
+    if _, err = w.Write([]byte("line4\n")); err != nil {
+        t.Fatalf("expected write to reopen the file, got %v", err)
+    }
+    if data, _ := os.ReadFile(path); string(data) != "line4\n" {
+        t.Errorf("expected reopened file to hold new line, got %q", data)
+    }
+}